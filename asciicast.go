@@ -0,0 +1,93 @@
+package animaterm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Asciicast is a recorded terminal session, see CreateRecorder
+type Asciicast struct {
+	Width  int
+	Height int
+	Title  string
+	Events []AsciicastEvent
+}
+
+// AsciicastEvent is output ("o") or a resize ("r") at Time since the start
+// of the recording. The data of a resize is the new size, e.g. "80x24".
+type AsciicastEvent struct {
+	Time time.Duration
+	Kind string
+	Data string
+}
+
+// ParseAsciicast reads a recording in the asciicast v2 format. Events other
+// than output and resizes are skipped.
+func ParseAsciicast(r io.Reader) (*Asciicast, error) {
+	decoder := json.NewDecoder(r)
+	var header asciicastHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, fmt.Errorf("reading asciicast header: %w", err)
+	}
+	if header.Version != 2 {
+		return nil, fmt.Errorf("unsupported asciicast version %d", header.Version)
+	}
+	cast := &Asciicast{Width: header.Width, Height: header.Height, Title: header.Title}
+	for i := 1; ; i++ {
+		var event []any
+		if err := decoder.Decode(&event); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading asciicast event %d: %w", i, err)
+		}
+		if len(event) != 3 {
+			return nil, fmt.Errorf("asciicast event %d is not [time, type, data]", i)
+		}
+		seconds, isTime := event[0].(float64)
+		kind, isKind := event[1].(string)
+		data, isData := event[2].(string)
+		if !isTime || !isKind || !isData {
+			return nil, fmt.Errorf("asciicast event %d is not [time, type, data]", i)
+		}
+		if kind != "o" && kind != "r" {
+			continue
+		}
+		cast.Events = append(cast.Events, AsciicastEvent{
+			Time: time.Duration(seconds * float64(time.Second)),
+			Kind: kind,
+			Data: data,
+		})
+	}
+	return cast, nil
+}
+
+// LoadAsciicast parses the recording name from fsys, see ParseAsciicast
+func LoadAsciicast(fsys fs.FS, name string) (*Asciicast, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	cast, err := ParseAsciicast(file)
+	if err != nil {
+		return nil, fmt.Errorf("parsing asciicast %s: %w", name, err)
+	}
+	return cast, nil
+}
+
+// parseSize reads the data of a resize event
+func parseSize(data string) (int, int, bool) {
+	w, h, found := strings.Cut(data, "x")
+	width, err := strconv.Atoi(w)
+	height, err2 := strconv.Atoi(h)
+	if !found || err != nil || err2 != nil || width <= 0 || height <= 0 {
+		return 0, 0, false
+	}
+	return width, height, true
+}
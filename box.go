@@ -0,0 +1,76 @@
+package animaterm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BorderStyle holds the glyphs used to draw the edges and corners of a box.
+// The junctions and inner lines are used where boxes are subdivided, e.g.
+// between the columns of a table.
+type BorderStyle struct {
+	TopLeft         string
+	Top             string
+	TopRight        string
+	Left            string
+	Right           string
+	BottomLeft      string
+	Bottom          string
+	BottomRight     string
+	TopJoin         string
+	BottomJoin      string
+	LeftJoin        string
+	RightJoin       string
+	Cross           string
+	InnerHorizontal string
+	InnerVertical   string
+}
+
+// Built-in border styles for DrawBox, panels and tables
+var (
+	BorderSingle = BorderStyle{
+		TopLeft: "┌", Top: "─", TopRight: "┐", Left: "│", Right: "│", BottomLeft: "└", Bottom: "─", BottomRight: "┘",
+		TopJoin: "┬", BottomJoin: "┴", LeftJoin: "├", RightJoin: "┤", Cross: "┼", InnerHorizontal: "─", InnerVertical: "│",
+	}
+	BorderDouble = BorderStyle{
+		TopLeft: "╔", Top: "═", TopRight: "╗", Left: "║", Right: "║", BottomLeft: "╚", Bottom: "═", BottomRight: "╝",
+		TopJoin: "╦", BottomJoin: "╩", LeftJoin: "╠", RightJoin: "╣", Cross: "╬", InnerHorizontal: "═", InnerVertical: "║",
+	}
+	BorderRounded = BorderStyle{
+		TopLeft: "╭", Top: "─", TopRight: "╮", Left: "│", Right: "│", BottomLeft: "╰", Bottom: "─", BottomRight: "╯",
+		TopJoin: "┬", BottomJoin: "┴", LeftJoin: "├", RightJoin: "┤", Cross: "┼", InnerHorizontal: "─", InnerVertical: "│",
+	}
+	BorderHeavy = BorderStyle{
+		TopLeft: "┏", Top: "━", TopRight: "┓", Left: "┃", Right: "┃", BottomLeft: "┗", Bottom: "━", BottomRight: "┛",
+		TopJoin: "┳", BottomJoin: "┻", LeftJoin: "┣", RightJoin: "┫", Cross: "╋", InnerHorizontal: "━", InnerVertical: "┃",
+	}
+	BorderASCII = BorderStyle{
+		TopLeft: "+", Top: "-", TopRight: "+", Left: "|", Right: "|", BottomLeft: "+", Bottom: "-", BottomRight: "+",
+		TopJoin: "+", BottomJoin: "+", LeftJoin: "+", RightJoin: "+", Cross: "+", InnerHorizontal: "-", InnerVertical: "|",
+	}
+)
+
+// DrawBox draws the outline of rect with the glyphs of border. A non-empty
+// title is embedded into the top edge and truncated to fit. The inside of
+// the box is left untouched. rect must be at least 2x2 cells.
+func (ui *UserInterface) DrawBox(rect Rect, border BorderStyle, title string, color int) error {
+	if rect.Width < 2 || rect.Height < 2 {
+		return fmt.Errorf("box must be at least 2x2 cells, got %dx%d", rect.Width, rect.Height)
+	}
+	inner := rect.Width - 2
+	top := strings.Repeat(border.Top, inner)
+	if title != "" && inner > 4 {
+		label := " " + ellipsis(title, inner-4) + " "
+		top = border.Top + label + strings.Repeat(border.Top, inner-1-displayWidth(label))
+	}
+	bottom := rect.Y + rect.Height - 1
+	right := rect.X + rect.Width - 1
+
+	ui.DrawClipped(rect, rect.X, rect.Y, border.TopLeft+top+border.TopRight, color)
+	for y := rect.Y + 1; y < bottom; y++ {
+		ui.DrawClipped(rect, rect.X, y, border.Left, color)
+		ui.DrawClipped(rect, right, y, border.Right, color)
+	}
+	ui.DrawClipped(rect, rect.X, bottom, border.BottomLeft+strings.Repeat(border.Bottom, inner)+border.BottomRight, color)
+	return nil
+}
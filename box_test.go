@@ -0,0 +1,102 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+)
+
+// row returns the uncolored content of a row of the pixel buffer
+func row(ui *UserInterface, y int, x int, width int) string {
+	return strings.Join(ui.pixels[y][x:x+width], "")
+}
+
+func TestDrawBox(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+
+	if err := ui.DrawBox(Rect{X: 0, Y: 0, Width: 1, Height: 5}, BorderSingle, "", ALREADYCOLORED); err == nil {
+		t.Error("DrawBox with a width of 1 should return an error")
+	}
+
+	if err := ui.DrawBox(Rect{X: 2, Y: 1, Width: 12, Height: 3}, BorderRounded, "Logs", ALREADYCOLORED); err != nil {
+		t.Fatalf("DrawBox returned error: %v", err)
+	}
+	expected := []string{
+		"╭─ Logs ───╮",
+		"│          │",
+		"╰──────────╯",
+	}
+	for i, line := range expected {
+		if got := row(ui, 1+i, 2, 12); got != line {
+			t.Errorf("row %d = %q, want %q", i, got, line)
+		}
+	}
+
+	_ = ui.DrawBox(Rect{X: 0, Y: 10, Width: 10, Height: 2}, BorderASCII, "a long title", ALREADYCOLORED)
+	if got := row(ui, 10, 0, 10); got != "+- a l… -+" {
+		t.Errorf("truncated title = %q", got)
+	}
+}
+
+func TestPanelContentClipping(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	panel := CreatePanel(ui, CreatePos(0, 0), 20, 30, BorderDouble, "", ALREADYCOLORED)
+	content := panel.Content()
+	bounds := content.Bounds()
+
+	content.DrawAt(0, 0, strings.Repeat("x", bounds.Width+5), ALREADYCOLORED)
+	if ui.pixels[bounds.Y][bounds.X+bounds.Width] != "║" {
+		t.Errorf("content overwrote the right edge: %q", ui.pixels[bounds.Y][bounds.X+bounds.Width])
+	}
+	if ui.pixels[bounds.Y][bounds.X] != "x" {
+		t.Errorf("content not drawn at top-left of viewport: %q", ui.pixels[bounds.Y][bounds.X])
+	}
+
+	content.DrawElement(CreatePos(0, 100), "a\nb", ALREADYCOLORED)
+	if ui.pixels[bounds.Y+bounds.Height][bounds.X] != "═" {
+		t.Errorf("content overwrote the bottom edge: %q", ui.pixels[bounds.Y+bounds.Height][bounds.X])
+	}
+
+	content.Clear()
+	if ui.pixels[bounds.Y][bounds.X] != " " {
+		t.Error("Clear() did not blank the viewport")
+	}
+}
+
+func TestViewportStylesAcrossLines(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	view := CreateViewport(ui, Rect{X: 2, Y: 1, Width: 10, Height: 5})
+
+	view.DrawElement(CreatePos(0, 0), "[red]a\nb[/]c", MARKUP)
+	for _, cell := range []struct{ x, y, fg int }{{2, 1, RED}, {2, 2, RED}, {3, 2, DEFAULTCOLOR}} {
+		if got := ui.styleAt(cell.x, cell.y).Fg; got != cell.fg {
+			t.Errorf("color at %d,%d = %d, want %d", cell.x, cell.y, got, cell.fg)
+		}
+	}
+
+	view.DrawElement(CreateMixedPos(Coord{}, Coord{Cells: 3}), "\033[1mx\ny\033[0m", ALREADYCOLORED)
+	if !ui.styleAt(2, 5).Bold {
+		t.Error("bold did not carry over to the second line")
+	}
+
+	// tags take no room when aligning the element
+	view.DrawElement(CreatePos(100, 0).SetAnchor(AnchorTopRight), "[red]ab[/]", MARKUP)
+	if got := stripANSI(row(ui, 1, 10, 2)); got != "ab" {
+		t.Errorf("right aligned row = %q", got)
+	}
+}
+
+func TestPanelContentFollowsBounds(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	panel := CreatePanel(ui, CreatePos(0, 0), 20, 30, BorderSingle, "", ALREADYCOLORED)
+	content := panel.Content()
+	panel.SetBounds(Rect{X: 10, Y: 5, Width: 8, Height: 4})
+
+	if got := content.Bounds(); got != (Rect{X: 11, Y: 6, Width: 6, Height: 2}) {
+		t.Errorf("content bounds after SetBounds = %+v", got)
+	}
+	content.DrawAt(0, 0, "x", ALREADYCOLORED)
+	if ui.pixels[6][11] != "x" {
+		t.Errorf("content drawn at stale coordinates, got %q at 11,6", ui.pixels[6][11])
+	}
+}
@@ -0,0 +1,185 @@
+package animaterm
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGB is a 24 bit sRGB color. It converts to the HSL and OKLab color spaces
+// and to the nearest index of the 256 color palette used for drawing.
+type RGB struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+// HSL is a color given by hue in degrees [0, 360), saturation and lightness [0, 1]
+type HSL struct {
+	H float64
+	S float64
+	L float64
+}
+
+// OKLab is a color in the perceptual OKLab color space. Equal distances in
+// OKLab are perceived as equal changes of color, which makes it the space to
+// interpolate gradients in.
+type OKLab struct {
+	L float64
+	A float64
+	B float64
+}
+
+// systemColors are the xterm defaults of the first 16 palette entries
+var systemColors = []RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// PaletteColor returns the RGB value of an index of the 256 color palette.
+// Indices outside the palette return black.
+func PaletteColor(index int) RGB {
+	switch {
+	case index < 0 || index > 255:
+		return RGB{}
+	case index < 16:
+		return systemColors[index]
+	case index < 232:
+		index -= 16
+		return RGB{uint8(cubeLevels[index/36]), uint8(cubeLevels[index/6%6]), uint8(cubeLevels[index%6])}
+	default:
+		level := uint8(8 + 10*(index-232))
+		return RGB{level, level, level}
+	}
+}
+
+// ParseHex parses colors written as "#rrggbb" or "#rgb", the # is optional
+func ParseHex(s string) (RGB, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return RGB{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	return RGB{uint8(value >> 16), uint8(value >> 8), uint8(value)}, nil
+}
+
+// Hex returns the color as "#rrggbb"
+func (c RGB) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Palette returns the index of the closest color of the 256 color palette.
+// Only the color cube and the grey ramp are considered, as the first 16
+// colors depend on the terminal's theme.
+func (c RGB) Palette() int {
+	return rgbToPalette(int(c.R), int(c.G), int(c.B))
+}
+
+// HSL converts the color to hue, saturation and lightness
+func (c RGB) HSL() HSL {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	high, low := max(r, g, b), min(r, g, b)
+	hsl := HSL{L: (high + low) / 2}
+	if high == low {
+		return hsl
+	}
+	delta := high - low
+	hsl.S = delta / (1 - math.Abs(2*hsl.L-1))
+	switch high {
+	case r:
+		hsl.H = math.Mod((g-b)/delta+6, 6)
+	case g:
+		hsl.H = (b-r)/delta + 2
+	default:
+		hsl.H = (r-g)/delta + 4
+	}
+	hsl.H *= 60
+	return hsl
+}
+
+// RGB converts the color to sRGB
+func (c HSL) RGB() RGB {
+	h := math.Mod(math.Mod(c.H, 360)+360, 360) / 60
+	chroma := (1 - math.Abs(2*c.L-1)) * c.S
+	x := chroma * (1 - math.Abs(math.Mod(h, 2)-1))
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g = chroma, x
+	case 1:
+		r, g = x, chroma
+	case 2:
+		g, b = chroma, x
+	case 3:
+		g, b = x, chroma
+	case 4:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := c.L - chroma/2
+	return RGB{toByte(r + m), toByte(g + m), toByte(b + m)}
+}
+
+// OKLab converts the color to the OKLab color space
+func (c RGB) OKLab() OKLab {
+	r, g, b := toLinear(c.R), toLinear(c.G), toLinear(c.B)
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// RGB converts the color to sRGB, colors outside the sRGB gamut are clipped
+func (c OKLab) RGB() RGB {
+	l := math.Pow(c.L+0.3963377774*c.A+0.2158037573*c.B, 3)
+	m := math.Pow(c.L-0.1055613458*c.A-0.0638541728*c.B, 3)
+	s := math.Pow(c.L-0.0894841775*c.A-1.2914855480*c.B, 3)
+	return RGB{
+		fromLinear(4.0767416621*l - 3.3077115913*m + 0.2309699292*s),
+		fromLinear(-1.2684380046*l + 2.6097574011*m - 0.3413193965*s),
+		fromLinear(-0.0041960863*l - 0.7034186147*m + 1.7076147010*s),
+	}
+}
+
+// Lerp interpolates between c and to in OKLab, factor 0 returns c and 1 returns to
+func (c RGB) Lerp(to RGB, factor float64) RGB {
+	a, b := c.OKLab(), to.OKLab()
+	return OKLab{
+		L: a.L + (b.L-a.L)*factor,
+		A: a.A + (b.A-a.A)*factor,
+		B: a.B + (b.B-a.B)*factor,
+	}.RGB()
+}
+
+// toLinear converts an sRGB channel into linear light
+func toLinear(channel uint8) float64 {
+	v := float64(channel) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// fromLinear converts linear light into a gamma encoded sRGB channel
+func fromLinear(v float64) uint8 {
+	if v <= 0.0031308 {
+		return toByte(12.92 * v)
+	}
+	return toByte(1.055*math.Pow(v, 1/2.4) - 0.055)
+}
+
+// toByte scales a channel from [0, 1] to [0, 255], clipping values outside
+func toByte(v float64) uint8 {
+	return uint8(math.Round(min(max(v, 0), 1) * 255))
+}
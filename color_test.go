@@ -0,0 +1,127 @@
+package animaterm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPaletteColor(t *testing.T) {
+	tests := []struct {
+		index int
+		want  RGB
+	}{
+		{index: 1, want: RGB{205, 0, 0}},
+		{index: 16, want: RGB{0, 0, 0}},
+		{index: 82, want: RGB{95, 255, 0}},
+		{index: 231, want: RGB{255, 255, 255}},
+		{index: 232, want: RGB{8, 8, 8}},
+		{index: 255, want: RGB{238, 238, 238}},
+		{index: BLANK, want: RGB{}},
+	}
+	for _, tt := range tests {
+		if got := PaletteColor(tt.index); got != tt.want {
+			t.Errorf("PaletteColor(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+	// every color of the cube and the grey ramp maps back onto itself
+	for index := 16; index < 256; index++ {
+		if got := PaletteColor(index).Palette(); got != index {
+			t.Errorf("PaletteColor(%d).Palette() = %d", index, got)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RGB
+		wantErr bool
+	}{
+		{input: "#ff8000", want: RGB{255, 128, 0}},
+		{input: "0a0B0c", want: RGB{10, 11, 12}},
+		{input: "#f80", want: RGB{255, 136, 0}},
+		{input: "#ff80", wantErr: true},
+		{input: "#gg0000", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHex(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHex(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHex(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	if got := (RGB{255, 128, 0}).Hex(); got != "#ff8000" {
+		t.Errorf("Hex() = %q", got)
+	}
+}
+
+func TestColorSpaceRoundTrips(t *testing.T) {
+	for index := 0; index < 256; index++ {
+		c := PaletteColor(index)
+		if got := c.HSL().RGB(); got != c {
+			t.Errorf("HSL round trip of %v = %v", c, got)
+		}
+		if got := c.OKLab().RGB(); got != c {
+			t.Errorf("OKLab round trip of %v = %v", c, got)
+		}
+	}
+
+	hsl := RGB{255, 0, 0}.HSL()
+	if hsl.H != 0 || hsl.S != 1 || hsl.L != 0.5 {
+		t.Errorf("HSL of red = %+v", hsl)
+	}
+	if got := (HSL{H: 240, S: 1, L: 0.5}).RGB(); got != (RGB{0, 0, 255}) {
+		t.Errorf("HSL blue = %v", got)
+	}
+	if white := (RGB{255, 255, 255}).OKLab(); math.Abs(white.L-1) > 1e-6 || math.Abs(white.A) > 1e-6 {
+		t.Errorf("OKLab of white = %+v", white)
+	}
+}
+
+func TestLerp(t *testing.T) {
+	black, white := RGB{0, 0, 0}, RGB{255, 255, 255}
+	if got := black.Lerp(white, 0); got != black {
+		t.Errorf("Lerp(0) = %v", got)
+	}
+	if got := black.Lerp(white, 1); got != white {
+		t.Errorf("Lerp(1) = %v", got)
+	}
+	// halfway is perceptual mid grey, not the arithmetic mean of the channels
+	if got := black.Lerp(white, 0.5); got != (RGB{99, 99, 99}) {
+		t.Errorf("Lerp(0.5) = %v", got)
+	}
+}
+
+func TestGradientStep(t *testing.T) {
+	tests := []struct {
+		name  string
+		color int
+	}{
+		{name: "cube row", color: COLORPATTERNLIME},
+		{name: "cube row ending in blue", color: COLORPATTERNSKYLIGHT},
+		{name: "grey ramp", color: 240},
+		{name: "system color", color: RED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := PaletteColor(gradientStep(tt.color, 0)).OKLab()
+			end := gradientStep(tt.color, 1)
+			if end != gradientEnd(tt.color) {
+				t.Errorf("gradient of %d ends at %d, want %d", tt.color, end, gradientEnd(tt.color))
+			}
+			for i := 1; i <= 10; i++ {
+				current := PaletteColor(gradientStep(tt.color, float32(i)/10)).OKLab()
+				if distance := math.Hypot(math.Hypot(current.L-previous.L, current.A-previous.A), current.B-previous.B); distance > 0.2 {
+					t.Errorf("step %d of the gradient of %d jumps by %.2f", i, tt.color, distance)
+				}
+				previous = current
+			}
+		})
+	}
+	if got := gradientStep(ALREADYCOLORED, 0.5); got != ALREADYCOLORED {
+		t.Errorf("gradientStep changed a sentinel color to %d", got)
+	}
+}
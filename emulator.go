@@ -0,0 +1,418 @@
+package animaterm
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// emulator replays terminal output on a virtual screen. It understands the
+// cursor movement, erase, scroll and SGR sequences common programs emit;
+// everything else is dropped. Output may be fed in arbitrary pieces, an
+// escape sequence or character cut in two is completed by the next write.
+type emulator struct {
+	width        int
+	height       int
+	cells        [][]styledCluster
+	primary      [][]styledCluster
+	x            int
+	y            int
+	savedX       int
+	savedY       int
+	wrapNext     bool
+	scrollTop    int
+	scrollBottom int
+	parser       *ansiParser
+	pending      string
+	dirty        bool
+}
+
+func newEmulator(width int, height int) *emulator {
+	e := &emulator{parser: newANSIParser()}
+	e.resize(width, height)
+	return e
+}
+
+// blank returns an empty cell in the current background
+func (e *emulator) blank() styledCluster {
+	style := DefaultStyle
+	style.Bg = e.parser.style.Bg
+	return styledCluster{text: " ", style: style}
+}
+
+// resize changes the size of the screen, keeping its top-left content
+func (e *emulator) resize(width int, height int) {
+	width, height = max(width, 1), max(height, 1)
+	cells := make([][]styledCluster, height)
+	for y := range cells {
+		cells[y] = make([]styledCluster, width)
+		for x := range cells[y] {
+			cells[y][x] = styledCluster{text: " ", style: DefaultStyle}
+			if y < len(e.cells) && x < len(e.cells[y]) {
+				cells[y][x] = e.cells[y][x]
+			}
+		}
+		// a wide cluster cut off at the right edge
+		if width < e.width && y < len(e.cells) && e.cells[y][width].text == continuationCell {
+			cells[y][width-1].text = " "
+		}
+	}
+	e.cells, e.width, e.height = cells, width, height
+	e.scrollTop, e.scrollBottom = 0, height-1
+	e.x, e.y = min(e.x, width-1), min(e.y, height-1)
+	e.wrapNext = false
+	e.dirty = true
+}
+
+// write feeds output to the screen
+func (e *emulator) write(data string) {
+	data = e.pending + data
+	e.pending = ""
+	if data != "" {
+		e.dirty = true
+	}
+	for i := 0; i < len(data); {
+		switch c := data[i]; {
+		case c == '\033':
+			length, ok := e.escape(data[i:])
+			if !ok {
+				e.pending = data[i:]
+				return
+			}
+			i += length
+		case c < 0x20 || c == 0x7f:
+			e.control(c)
+			i++
+		default:
+			end := i
+			for end < len(data) && data[end] >= 0x20 && data[end] != 0x7f {
+				end++
+			}
+			text := data[i:end]
+			if end == len(data) {
+				// keep a character cut in two for the next write
+				for cut := 1; cut < utf8.UTFMax && cut <= len(text); cut++ {
+					if utf8.RuneStart(text[len(text)-cut]) {
+						if !utf8.FullRuneInString(text[len(text)-cut:]) {
+							e.pending = text[len(text)-cut:]
+							text = text[:len(text)-cut]
+						}
+						break
+					}
+				}
+			}
+			for _, cluster := range graphemes(text) {
+				e.put(cluster)
+			}
+			i = end
+		}
+	}
+}
+
+// control handles a C0 control character
+func (e *emulator) control(c byte) {
+	switch c {
+	case '\r':
+		e.moveTo(0, e.y)
+	case '\n', '\v', '\f':
+		e.wrapNext = false
+		e.lineFeed()
+	case '\b':
+		e.moveTo(e.x-1, e.y)
+	case '\t':
+		e.moveTo((e.x/8+1)*8, e.y)
+	}
+}
+
+// put draws a cluster at the cursor and advances it, wrapping at the right edge
+func (e *emulator) put(cluster string) {
+	width := clusterWidth(cluster)
+	if width == 0 || width > e.width {
+		return
+	}
+	if e.wrapNext || e.x+width > e.width {
+		e.x = 0
+		e.lineFeed()
+	}
+	e.wrapNext = false
+	e.set(e.x, e.y, styledCluster{text: cluster, style: e.parser.style})
+	if width == 2 {
+		e.set(e.x+1, e.y, styledCluster{text: continuationCell, style: e.parser.style})
+	}
+	e.x += width
+	if e.x >= e.width {
+		e.x = e.width - 1
+		e.wrapNext = true
+	}
+}
+
+// set replaces a cell. Overwriting either half of a wide cluster blanks its
+// other half.
+func (e *emulator) set(x int, y int, cell styledCluster) {
+	row := e.cells[y]
+	if cell.text != continuationCell && row[x].text == continuationCell && x > 0 {
+		row[x-1].text = " "
+	}
+	if x+1 < e.width && row[x+1].text == continuationCell {
+		row[x+1].text = " "
+	}
+	row[x] = cell
+}
+
+// moveTo moves the cursor, clamped to the screen
+func (e *emulator) moveTo(x int, y int) {
+	e.x = min(max(x, 0), e.width-1)
+	e.y = min(max(y, 0), e.height-1)
+	e.wrapNext = false
+}
+
+// lineFeed moves the cursor down, scrolling at the bottom of the scroll region
+func (e *emulator) lineFeed() {
+	switch {
+	case e.y == e.scrollBottom:
+		e.scroll(e.scrollTop, e.scrollBottom, 1)
+	case e.y < e.height-1:
+		e.y++
+	}
+}
+
+// reverseLineFeed moves the cursor up, scrolling at the top of the scroll region
+func (e *emulator) reverseLineFeed() {
+	switch {
+	case e.y == e.scrollTop:
+		e.scroll(e.scrollTop, e.scrollBottom, -1)
+	case e.y > 0:
+		e.y--
+	}
+}
+
+// scroll moves the rows from top to bottom up by n rows, or down for a
+// negative n, filling in blank rows
+func (e *emulator) scroll(top int, bottom int, n int) {
+	rows := e.cells[top : bottom+1]
+	n = max(min(n, len(rows)), -len(rows))
+	blankRow := func() []styledCluster {
+		row := make([]styledCluster, e.width)
+		for x := range row {
+			row[x] = e.blank()
+		}
+		return row
+	}
+	if n > 0 {
+		copy(rows, rows[n:])
+		for i := len(rows) - n; i < len(rows); i++ {
+			rows[i] = blankRow()
+		}
+	} else {
+		copy(rows[-n:], rows)
+		for i := 0; i < -n; i++ {
+			rows[i] = blankRow()
+		}
+	}
+}
+
+// erase blanks the cells from x0 to x1 (exclusive) in row y
+func (e *emulator) erase(y int, x0 int, x1 int) {
+	for x := max(x0, 0); x < min(x1, e.width); x++ {
+		e.set(x, y, e.blank())
+	}
+}
+
+// escape handles the escape sequence at the start of s and returns its
+// length. Returns false if s ends before the sequence is complete.
+func (e *emulator) escape(s string) (int, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	switch s[1] {
+	case '[':
+		length, final, params := scanEscape(s)
+		if final == 0 {
+			return 0, false
+		}
+		e.csi(final, params)
+		return length, true
+	case ']', 'P', '_', '^':
+		// strings are terminated by BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1, true
+			}
+			if s[i] == '\033' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, true
+			}
+		}
+		return 0, false
+	case '(', ')', '*', '+', '#', '%':
+		// character set designations carry one more byte
+		if len(s) < 3 {
+			return 0, false
+		}
+		return 3, true
+	case '7':
+		e.savedX, e.savedY = e.x, e.y
+	case '8':
+		e.moveTo(e.savedX, e.savedY)
+	case 'D':
+		e.lineFeed()
+	case 'E':
+		e.x = 0
+		e.lineFeed()
+	case 'M':
+		e.reverseLineFeed()
+	case 'c':
+		*e = *newEmulator(e.width, e.height)
+	}
+	return 2, true
+}
+
+// maxArgument caps the numeric arguments of control sequences
+const maxArgument = 1 << 16
+
+// csi handles a control sequence with its final byte and parameters
+func (e *emulator) csi(final byte, params string) {
+	private := strings.HasPrefix(params, "?")
+	args := strings.Split(strings.TrimLeft(params, "?>="), ";")
+	// arg returns the i-th argument, or fallback if it is missing, 0 or not
+	// a plain number. Arguments are capped so that moving the cursor by them
+	// cannot overflow.
+	arg := func(i int, fallback int) int {
+		if i >= len(args) || strings.Trim(args[i], "0123456789") != "" {
+			return fallback
+		}
+		n, err := strconv.Atoi(args[i])
+		if err != nil || n == 0 {
+			return fallback
+		}
+		return min(n, maxArgument)
+	}
+	switch final {
+	case 'A':
+		e.moveTo(e.x, e.y-arg(0, 1))
+	case 'B', 'e':
+		e.moveTo(e.x, e.y+arg(0, 1))
+	case 'C', 'a':
+		e.moveTo(e.x+arg(0, 1), e.y)
+	case 'D':
+		e.moveTo(e.x-arg(0, 1), e.y)
+	case 'E':
+		e.moveTo(0, e.y+arg(0, 1))
+	case 'F':
+		e.moveTo(0, e.y-arg(0, 1))
+	case 'G', '`':
+		e.moveTo(arg(0, 1)-1, e.y)
+	case 'd':
+		e.moveTo(e.x, arg(0, 1)-1)
+	case 'H', 'f':
+		e.moveTo(arg(1, 1)-1, arg(0, 1)-1)
+	case 'J':
+		switch arg(0, 0) {
+		case 0:
+			e.erase(e.y, e.x, e.width)
+			for y := e.y + 1; y < e.height; y++ {
+				e.erase(y, 0, e.width)
+			}
+		case 1:
+			for y := 0; y < e.y; y++ {
+				e.erase(y, 0, e.width)
+			}
+			e.erase(e.y, 0, e.x+1)
+		default:
+			for y := 0; y < e.height; y++ {
+				e.erase(y, 0, e.width)
+			}
+		}
+	case 'K':
+		switch arg(0, 0) {
+		case 0:
+			e.erase(e.y, e.x, e.width)
+		case 1:
+			e.erase(e.y, 0, e.x+1)
+		default:
+			e.erase(e.y, 0, e.width)
+		}
+	case 'X':
+		e.erase(e.y, e.x, e.x+arg(0, 1))
+	case '@', 'P':
+		row := e.cells[e.y]
+		n := min(arg(0, 1), e.width-e.x)
+		if final == '@' {
+			copy(row[e.x+n:], row[e.x:])
+			e.erase(e.y, e.x, e.x+n)
+		} else {
+			copy(row[e.x:], row[e.x+n:])
+			e.erase(e.y, e.width-n, e.width)
+		}
+	case 'L', 'M':
+		if e.y >= e.scrollTop && e.y <= e.scrollBottom {
+			n := arg(0, 1)
+			if final == 'L' {
+				n = -n
+			}
+			e.scroll(e.y, e.scrollBottom, n)
+			e.x = 0
+		}
+	case 'S':
+		e.scroll(e.scrollTop, e.scrollBottom, arg(0, 1))
+	case 'T':
+		e.scroll(e.scrollTop, e.scrollBottom, -arg(0, 1))
+	case 'r':
+		top, bottom := arg(0, 1)-1, arg(1, e.height)-1
+		if 0 <= top && top < bottom && bottom < e.height {
+			e.scrollTop, e.scrollBottom = top, bottom
+			e.moveTo(0, 0)
+		}
+	case 's':
+		e.savedX, e.savedY = e.x, e.y
+	case 'u':
+		e.moveTo(e.savedX, e.savedY)
+	case 'm':
+		if !private {
+			e.parser.apply(params)
+		}
+	case 'h', 'l':
+		if private && (arg(0, 0) == 1049 || arg(0, 0) == 47 || arg(0, 0) == 1047) {
+			e.alternateScreen(final == 'h')
+		}
+	}
+}
+
+// alternateScreen switches to an empty screen and back to the one before
+func (e *emulator) alternateScreen(on bool) {
+	switch {
+	case on && e.primary == nil:
+		e.primary = e.cells
+		e.savedX, e.savedY = e.x, e.y
+		e.cells = nil
+		e.resize(e.width, e.height)
+	case !on && e.primary != nil:
+		e.cells = nil
+		e.resize(e.width, e.height)
+		for y := range e.cells {
+			if y < len(e.primary) {
+				copy(e.cells[y], e.primary[y])
+			}
+		}
+		e.primary = nil
+		e.moveTo(e.savedX, e.savedY)
+	}
+}
+
+// line renders row y from column 0 to width as pre-colored text. A wide
+// cluster cut in half at the end is replaced by a space.
+func (e *emulator) line(y int, width int) string {
+	var sb strings.Builder
+	row := e.cells[y]
+	for x := 0; x < min(width, e.width); x++ {
+		cell := row[x]
+		switch {
+		case cell.text == continuationCell:
+			continue
+		case x+1 == width && x+1 < e.width && row[x+1].text == continuationCell:
+			sb.WriteString(cell.style.Render(" "))
+		default:
+			sb.WriteString(cell.style.Render(cell.text))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+)
+
+// screenText returns the uncolored rows of the emulator's screen
+func screenText(e *emulator) string {
+	rows := []string{}
+	for y := range e.cells {
+		rows = append(rows, stripANSI(e.line(y, e.width)))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func TestEmulator(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{name: "text and newlines", writes: []string{"ab\r\ncd"}, want: []string{"ab  ", "cd  ", "    "}},
+		{name: "wraps at the right edge", writes: []string{"abcdef"}, want: []string{"abcd", "ef  ", "    "}},
+		{name: "scrolls at the bottom", writes: []string{"1\r\n2\r\n3\r\n4"}, want: []string{"2   ", "3   ", "4   "}},
+		{name: "cursor position", writes: []string{"\033[2;3Hx\033[1;1Hy"}, want: []string{"y   ", "  x ", "    "}},
+		{name: "relative movement", writes: []string{"a\033[Bb\033[2Dc\033[Ad"}, want: []string{"ad  ", "cb  ", "    "}},
+		{name: "erase line", writes: []string{"abcd\033[1;3H\033[K"}, want: []string{"ab  ", "    ", "    "}},
+		{name: "erase screen", writes: []string{"abcd\r\nefgh\033[2J"}, want: []string{"    ", "    ", "    "}},
+		{name: "erase below", writes: []string{"abcd\r\nefgh\r\nijkl\033[2;2H\033[J"}, want: []string{"abcd", "e   ", "    "}},
+		{name: "backspace and tab", writes: []string{"ab\bc\r\n\tx"}, want: []string{"ac  ", "   x", "    "}},
+		{name: "wide characters", writes: []string{"a世b"}, want: []string{"a世b", "    ", "    "}},
+		{name: "wide character overwritten", writes: []string{"世\033[1;2Hx"}, want: []string{" x  ", "    ", "    "}},
+		{name: "insert and delete characters", writes: []string{"abcd\033[1;2H\033[P\033[1;1H\033[@"}, want: []string{" acd", "    ", "    "}},
+		{name: "insert line", writes: []string{"1\r\n2\033[1;1H\033[L"}, want: []string{"    ", "1   ", "2   "}},
+		{name: "save and restore cursor", writes: []string{"a\0337\033[3;3Hz\0338b"}, want: []string{"ab  ", "    ", "  z "}},
+		{name: "alternate screen", writes: []string{"main\033[?1049hfull\033[?1049l"}, want: []string{"main", "    ", "    "}},
+		{name: "sequences split across writes", writes: []string{"\033[2", ";2Hx", "\xe4\xb8", "\x96"}, want: []string{"    ", " x世", "    "}},
+		{name: "titles and charsets are dropped", writes: []string{"\033]0;title\a\033(Bok"}, want: []string{"ok  ", "    ", "    "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEmulator(4, 3)
+			for _, w := range tt.writes {
+				e.write(w)
+			}
+			if got, want := screenText(e), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("screen =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestEmulatorMalformedSequences(t *testing.T) {
+	tests := []struct {
+		name  string
+		write string
+		want  []string
+	}{
+		{name: "negative scroll region", write: "a\033[-5;3r\n\n\n\nb", want: []string{"    ", "    ", "b   "}},
+		{name: "negative insert", write: "abcd\033[1;2H\033[-3@", want: []string{"a bc", "    ", "    "}},
+		{name: "negative delete", write: "abcd\033[1;2H\033[-3P", want: []string{"acd ", "    ", "    "}},
+		{name: "signed cursor position", write: "\033[+2;-3Hx", want: []string{"x   ", "    ", "    "}},
+		{name: "huge arguments", write: "ab\033[99999999999999999999X\033[9223372036854775807Cx\033[9223372036854775807@", want: []string{"ab  ", "    ", "    "}},
+		{name: "garbage arguments", write: "\033[2:1;1Hy\033[1;2;3;4;5Lz", want: []string{"z   ", "y   ", "    "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEmulator(4, 3)
+			e.write(tt.write)
+			if got, want := screenText(e), strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("screen =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestEmulatorStyles(t *testing.T) {
+	e := newEmulator(4, 1)
+	e.write("\033[1;31ma\033[0mb")
+	if got := e.cells[0][0].style; got.Fg != 1 || !got.Bold {
+		t.Errorf("style of a = %+v", got)
+	}
+	if got := e.cells[0][1].style; got != DefaultStyle {
+		t.Errorf("style of b = %+v", got)
+	}
+	// erasing fills in the current background
+	e.write("\033[44m\033[K")
+	if got := e.cells[0][3].style.Bg; got != 4 {
+		t.Errorf("erased cell background = %d, want 4", got)
+	}
+}
+
+func TestEmulatorResize(t *testing.T) {
+	e := newEmulator(4, 2)
+	e.write("ab世\r\ncdef")
+	e.resize(3, 3)
+	if got, want := screenText(e), "ab \ncde\n   "; got != want {
+		t.Errorf("screen after resize =\n%s\nwant\n%s", got, want)
+	}
+	if e.x != 2 || e.y != 1 {
+		t.Errorf("cursor after resize = %d,%d", e.x, e.y)
+	}
+}
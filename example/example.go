@@ -4,7 +4,6 @@ import (
 	"strconv"
 	"time"
 
-	figure "github.com/common-nighthawk/go-figure"
 	ui "github.com/gregod-com/animaterm"
 )
 
@@ -20,13 +19,18 @@ func main() {
 
 	var duration int64 = 800
 
+	font, err := ui.BundledFont("standard")
+	if err != nil {
+		panic(err)
+	}
+
 	ch, wg := myUI.StartDrawLoop(70)
-	iamASCII := figure.NewFigure("Staging", "standard", true)
+	iamASCII, _, _ := font.Render("Staging")
 
 	if err := myUI.MoveElement(
-		ui.CreatePos(70, 15),
-		ui.CreatePos(35, 15),
-		iamASCII.String(),
+		ui.CreatePos(85, 15).SetAnchor(ui.AnchorTop),
+		ui.CreatePos(50, 15),
+		iamASCII,
 		ui.COLORPATTERNMEADOWS1,
 		ui.Animation{
 			AnimationType: ui.Ikea,
@@ -35,8 +39,6 @@ func main() {
 		panic(err)
 	}
 
-	close(ch)
-	wg.Wait()
 	time.Sleep(time.Duration(500) * time.Millisecond)
 	for i := 10; i <= 100; i += 10 {
 		go myUI.DrawPattern(ui.CreatePos(0, i-10), i, "█\n█\n", ui.COLORPATTERNNEON1,
@@ -58,10 +60,15 @@ func main() {
 		myUI.DrawElement(ui.CreatePos(50, i-6), strconv.Itoa(i), ui.COLORPATTERNNEON1)
 		time.Sleep(time.Duration(100) * time.Millisecond)
 	}
-	close(ch)
-	wg.Wait()
 
-	myUI.ClearScreen()
+	// open the screen like a curtain onto an empty one
+	if err := myUI.Transition(func(next ui.IUserInterface) {}, ui.TransitionCurtain,
+		ui.Animation{
+			Duration:      1200,
+			AnimationType: ui.EaseInOut,
+		}); err != nil {
+		panic(err)
+	}
 
 	for i := 10; i <= 100; i += 10 {
 		go myUI.DrawPattern(ui.CreatePos(i-10, 0), 50, "█", ui.COLORPATTERNNEON1,
@@ -151,9 +158,9 @@ func main() {
 		})
 
 	// ascii font going from center to left
-	myCLI := figure.NewFigure("animaterm", "standard", true)
+	myCLI, _, _ := font.Render("animaterm")
 	go func() {
-		if err := myUI.MoveElement(ui.CreatePos(40, 5), ui.CreatePos(0, 5), myCLI.String(), ui.COLORPATTERNLIME,
+		if err := myUI.MoveElement(ui.CreatePos(40, 5), ui.CreatePos(0, 5), myCLI, ui.COLORPATTERNLIME,
 			ui.Animation{
 				Duration:      duration,
 				AnimationType: ui.Ikea,
@@ -174,9 +181,9 @@ func main() {
 			Direction:     ui.Right,
 		})
 
-	animation := figure.NewFigure("a go animation", "standard", true)
+	animation, _, _ := font.Render("a go animation")
 	go func() {
-		if err := myUI.MoveElement(ui.CreatePos(27, 65), ui.CreatePos(14, 65), animation.String(), ui.COLORPATTERNLIME,
+		if err := myUI.MoveElement(ui.CreatePos(27, 65), ui.CreatePos(14, 65), animation, ui.COLORPATTERNLIME,
 			ui.Animation{
 				Duration:      800,
 				AnimationType: ui.Ikea,
@@ -188,8 +195,8 @@ func main() {
 		}
 	}()
 
-	framework := figure.NewFigure("framework", "standard", true)
-	if err := myUI.MoveElement(ui.CreatePos(15, 80), ui.CreatePos(40, 80), framework.String(), ui.COLORPATTERNGREY,
+	framework, _, _ := font.Render("framework")
+	if err := myUI.MoveElement(ui.CreatePos(15, 80), ui.CreatePos(40, 80), framework, ui.COLORPATTERNGREY,
 		ui.Animation{
 			Duration:      1600,
 			AnimationType: ui.Ikea,
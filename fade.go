@@ -0,0 +1,219 @@
+package animaterm
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/mobile/exp/sprite/clock"
+)
+
+// tweenCell is a cluster of an element at its absolute cell, animated from
+// one style to another
+type tweenCell struct {
+	x    int
+	y    int
+	text string
+	from Style
+	to   Style
+}
+
+// at returns the style of the cell at factor (0-1) of the animation.
+// Colors are interpolated in OKLab, attributes are those of the target. The
+// ends of the animation are the exact styles, since the nearest palette color
+// of an interpolated color may differ from the color it started from.
+func (c tweenCell) at(factor float64) Style {
+	if factor <= 0 {
+		return c.from
+	}
+	if factor >= 1 {
+		return c.to
+	}
+	style := c.to
+	style.Fg = PaletteColor(c.from.Fg).Lerp(PaletteColor(c.to.Fg), factor).Palette()
+	if c.from.Bg != DEFAULTCOLOR || c.to.Bg != DEFAULTCOLOR {
+		style.Bg = PaletteColor(c.from.Bg).Lerp(PaletteColor(c.to.Bg), factor).Palette()
+	}
+	return style
+}
+
+// FadeIn see IUserInterface
+func (ui *UserInterface) FadeIn(pos IRelativePosition, text string, color int, animation Animation) error {
+	if err := validateTween(pos, text, animation); err != nil {
+		return err
+	}
+	theme := CurrentTheme()
+	cells := ui.elementCells(pos, text, color)
+	for i, cell := range cells {
+		under := ui.styleAt(cell.x, cell.y)
+		if cell.to.Bg == DEFAULTCOLOR {
+			cell.to.Bg = under.Bg
+		}
+		cell.to.Fg = orColor(cell.to.Fg, theme.Background.Fg)
+		cell.from = cell.to
+		cell.from.Fg = orColor(cell.to.Bg, theme.Background.Bg)
+		cells[i] = cell
+	}
+	ui.tween(cells, animation)
+	return nil
+}
+
+// FadeOut see IUserInterface
+func (ui *UserInterface) FadeOut(pos IRelativePosition, text string, color int, animation Animation) error {
+	if err := validateTween(pos, text, animation); err != nil {
+		return err
+	}
+	theme := CurrentTheme()
+	cells := ui.elementCells(pos, text, color)
+	for i, cell := range cells {
+		if cell.to.Bg == DEFAULTCOLOR {
+			cell.to.Bg = ui.styleAt(cell.x, cell.y).Bg
+		}
+		cell.from = cell.to
+		cell.from.Fg = orColor(cell.to.Fg, theme.Background.Fg)
+		cell.to.Fg = orColor(cell.to.Bg, theme.Background.Bg)
+		cells[i] = cell
+	}
+	ui.tween(cells, animation)
+	// erase the element, keeping the background it was drawn over
+	screen := Rect{Width: ui.width, Height: ui.height}
+	for _, cell := range cells {
+		blank := DefaultStyle
+		blank.Bg = cell.to.Bg
+		for i := 0; i < max(clusterWidth(cell.text), 1); i++ {
+			ui.placeCluster(screen, (cell.x+i)%ui.width, cell.y, renderedCluster{" ", blank.Render})
+		}
+	}
+	return nil
+}
+
+// ColorTo see IUserInterface
+func (ui *UserInterface) ColorTo(pos IRelativePosition, text string, from Style, to Style, animation Animation) error {
+	if err := validateTween(pos, text, animation); err != nil {
+		return err
+	}
+	theme := CurrentTheme()
+	from.Fg = orColor(from.Fg, theme.Background.Fg)
+	to.Fg = orColor(to.Fg, theme.Background.Fg)
+	if from.Bg != DEFAULTCOLOR || to.Bg != DEFAULTCOLOR {
+		from.Bg = orColor(from.Bg, theme.Background.Bg)
+		to.Bg = orColor(to.Bg, theme.Background.Bg)
+	}
+	cells := ui.elementCells(pos, text, ALREADYCOLORED)
+	for i := range cells {
+		cells[i].from, cells[i].to = from, to
+	}
+	ui.tween(cells, animation)
+	return nil
+}
+
+func validateTween(pos IRelativePosition, text string, animation Animation) error {
+	if pos == nil {
+		return fmt.Errorf("position cannot be nil")
+	}
+	if text == "" {
+		return fmt.Errorf("text cannot be empty")
+	}
+	if animation.Duration < 0 {
+		return fmt.Errorf("animation duration cannot be negative")
+	}
+	return nil
+}
+
+// orColor returns color, or fallback for the terminal's default color
+func orColor(color int, fallback int) int {
+	if color < 0 || color > 255 {
+		return fallback
+	}
+	return color
+}
+
+// elementCells lays out text like DrawElement and returns every cluster at
+// its absolute cell with the style it would be drawn in as target
+func (ui *UserInterface) elementCells(pos IRelativePosition, text string, color int) []tweenCell {
+	cells := layoutCells(ui, pos, text, color)
+	for i := range cells {
+		cells[i].x %= ui.width
+		cells[i].y %= ui.height
+	}
+	return cells
+}
+
+// layoutCells places the clusters of text at their absolute cells, aligned to
+// pos by its anchor, with the style each one is drawn in as target
+func layoutCells(ui IUserInterface, pos IRelativePosition, text string, color int) []tweenCell {
+	text, color = resolveMarkup(text, color)
+	shiftX, shiftY := pos.GetAnchor().shift(measureText(text))
+	parser := newANSIParser()
+	cells := []tweenCell{}
+	for k, line := range getLines(text, false) {
+		linePos := CreateMixedPos(pos.Coords()).SetOffset(pos.GetOffset() + k)
+		absX, absY := ui.PositionToAbsolute(linePos)
+		y := absY + shiftY
+		var clusters []styledCluster
+		if color == ALREADYCOLORED {
+			clusters = parser.parse(line)
+		} else {
+			// mirror DrawElement, which shifts palette colors by the line number
+			style := DefaultStyle
+			if color >= 0 && color+k < 256 {
+				style.Fg = color + k
+			}
+			for _, cluster := range graphemes(line) {
+				clusters = append(clusters, styledCluster{text: cluster, style: style})
+			}
+		}
+		l := 0
+		for _, cluster := range clusters {
+			cells = append(cells, tweenCell{x: absX + l + shiftX, y: y, text: cluster.text, to: cluster.style})
+			l += clusterWidth(cluster.text)
+		}
+	}
+	return cells
+}
+
+// styleAt returns the style of the pixel at x and y
+func (ui *UserInterface) styleAt(x int, y int) Style {
+	ui.pixelsMutex.RLock()
+	defer ui.pixelsMutex.RUnlock()
+	if y < 0 || y >= len(ui.pixels) || x < 0 || x >= len(ui.pixels[y]) {
+		return DefaultStyle
+	}
+	pixel := ui.pixels[y][x]
+	if pixel == continuationCell && x > 0 {
+		pixel = ui.pixels[y][x-1]
+	}
+	if clusters := newANSIParser().parse(pixel); len(clusters) > 0 {
+		return clusters[0].style
+	}
+	return DefaultStyle
+}
+
+// tween draws cells every frame of animation with their interpolated styles
+// and blocks until the animation is complete
+func (ui *UserInterface) tween(cells []tweenCell, animation Animation) {
+	screen := Rect{Width: ui.width, Height: ui.height}
+	ui.animate(animation, func(factor float64) {
+		for _, cell := range cells {
+			ui.placeCluster(screen, cell.x, cell.y, renderedCluster{cell.text, cell.at(factor).Render})
+		}
+	})
+}
+
+// animate calls frame once per frame of animation with the eased factor
+// (0-1) and blocks until the animation is complete. The last frame is always
+// called with 1.
+func (ui *UserInterface) animate(animation Animation, frame func(factor float64)) {
+	ui.frameMutex.RLock()
+	frameRate := ui.msPerFrame
+	ui.frameMutex.RUnlock()
+	frames := int(animation.Duration / frameRate)
+	ease := getAnimation(animation.AnimationType)
+	for i := 0; i <= frames; i++ {
+		factor := 1.0
+		if i < frames {
+			factor = min(max(float64(ease(clock.Time(0), clock.Time(frames), clock.Time(i))), 0), 1)
+		}
+		frame(factor)
+		time.Sleep(time.Duration(frameRate) * time.Millisecond)
+	}
+}
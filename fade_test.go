@@ -0,0 +1,117 @@
+package animaterm
+
+import (
+	"testing"
+)
+
+func TestFadeIn(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	// a blue background underneath the second cell
+	ui.setPixel(1, 0, Style{Fg: DEFAULTCOLOR, Bg: 21}.Render(" "))
+
+	if err := ui.FadeIn(CreatePos(0, 0), "ab", COLORPATTERNLIME, Animation{}); err != nil {
+		t.Fatalf("FadeIn() error = %v", err)
+	}
+	if got, want := ui.pixels[0][0], (Style{Fg: COLORPATTERNLIME, Bg: DEFAULTCOLOR}).Render("a"); got != want {
+		t.Errorf("faded in cell = %q, want %q", got, want)
+	}
+	if got, want := ui.pixels[0][1], (Style{Fg: COLORPATTERNLIME, Bg: 21}).Render("b"); got != want {
+		t.Errorf("cell over a background = %q, want %q", got, want)
+	}
+}
+
+func TestFadeSteps(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	ui.setPixel(0, 0, Style{Fg: DEFAULTCOLOR, Bg: 21}.Render(" "))
+	cells := ui.elementCells(CreatePos(0, 0), "a", RED2)
+	cells[0].to.Bg = ui.styleAt(0, 0).Bg
+	cells[0].from = cells[0].to
+	cells[0].from.Fg = cells[0].to.Bg
+
+	if got := cells[0].at(0); got.Fg != 21 || got.Bg != 21 {
+		t.Errorf("start of the fade = %+v, want the background color", got)
+	}
+	middle := cells[0].at(0.5)
+	if middle.Fg == 21 || middle.Fg == RED2 {
+		t.Errorf("middle of the fade = %d, want a color in between", middle.Fg)
+	}
+	if got := cells[0].at(1); got.Fg != RED2 {
+		t.Errorf("end of the fade = %+v", got)
+	}
+}
+
+func TestFadeOut(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	ui.DrawElement(CreatePos(0, 0), "ab\ncd", COLORPATTERNLIME)
+	if err := ui.FadeOut(CreatePos(0, 0), "ab\ncd", COLORPATTERNLIME, Animation{Duration: ui.msPerFrame * 2}); err != nil {
+		t.Fatalf("FadeOut() error = %v", err)
+	}
+	if got := row(ui, 0, 0, 2) + row(ui, 1, 0, 2); got != "    " {
+		t.Errorf("faded out element left %q", got)
+	}
+}
+
+func TestFadeOutKeepsBackground(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ui.setPixel(1, 0, Style{Fg: DEFAULTCOLOR, Bg: 21}.Render(" "))
+	ui.DrawElement(CreatePos(0, 0), "ab", ALREADYCOLORED)
+	ui.setPixel(1, 0, Style{Fg: RED2, Bg: 21}.Render("b"))
+	if err := ui.FadeOut(CreatePos(0, 0), "ab", ALREADYCOLORED, Animation{}); err != nil {
+		t.Fatalf("FadeOut() error = %v", err)
+	}
+	if got := ui.pixels[0][0]; got != " " {
+		t.Errorf("erased cell = %q, want a blank", got)
+	}
+	if got, want := ui.pixels[0][1], (Style{Fg: DEFAULTCOLOR, Bg: 21}).Render(" "); got != want {
+		t.Errorf("erased cell over a background = %q, want %q", got, want)
+	}
+}
+
+func TestFadeEndsOnColor(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	if err := ui.FadeIn(CreatePos(0, 0), "x", RED, Animation{Duration: ui.msPerFrame * 2}); err != nil {
+		t.Fatalf("FadeIn() error = %v", err)
+	}
+	if got := ui.styleAt(0, 0); got.Fg != RED {
+		t.Errorf("color at the end of the fade = %d, want %d", got.Fg, RED)
+	}
+
+	cell := tweenCell{from: Style{Fg: 1, Bg: DEFAULTCOLOR}, to: Style{Fg: 9, Bg: 4}}
+	if got := cell.at(0); got != cell.from {
+		t.Errorf("at(0) = %+v, want %+v", got, cell.from)
+	}
+	if got := cell.at(1); got != cell.to {
+		t.Errorf("at(1) = %+v, want %+v", got, cell.to)
+	}
+}
+
+func TestColorTo(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	from := Style{Fg: RED2, Bg: DEFAULTCOLOR}
+	to := Style{Fg: COLORPATTERNLIME, Bg: DARKBLUE, Bold: true}
+	if err := ui.ColorTo(CreatePos(0, 0).SetAnchor(AnchorTopLeft), "[b]x", from, to, Animation{}); err != nil {
+		t.Fatalf("ColorTo() error = %v", err)
+	}
+	if got, want := ui.pixels[0][0], to.Render("["); got != want {
+		t.Errorf("ColorTo end = %q, want %q", got, want)
+	}
+	tests := []struct {
+		name      string
+		pos       IRelativePosition
+		text      string
+		animation Animation
+	}{
+		{name: "nil position", pos: nil, text: "x"},
+		{name: "empty text", pos: CreatePos(0, 0), text: ""},
+		{name: "negative duration", pos: CreatePos(0, 0), text: "x", animation: Animation{Duration: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ui.ColorTo(tt.pos, tt.text, from, to, tt.animation); err == nil {
+				t.Error("ColorTo() should return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package animaterm
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed fonts/*.flf
+var bundledFonts embed.FS
+
+// germanCharacters follow the ASCII characters in every FIGlet font
+var germanCharacters = []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'}
+
+// FigletFont is a parsed FIGlet font (.flf) used to render big text.
+// Characters are placed next to each other at their full width.
+type FigletFont struct {
+	height      int
+	hardblank   string
+	rightToLeft bool
+	glyphs      map[rune][]string
+}
+
+// ParseFigletFont reads a font in the FIGlet 2 format. Besides the required
+// ASCII and German characters, code tagged characters are read as well.
+func ParseFigletFont(r io.Reader) (*FigletFont, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("missing FIGlet header")
+	}
+	header := strings.Fields(scanner.Text())
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) == len("flf2a") {
+		return nil, fmt.Errorf("invalid FIGlet header %q", scanner.Text())
+	}
+	height, err := strconv.Atoi(header[1])
+	if err != nil || height < 1 {
+		return nil, fmt.Errorf("invalid FIGlet height %q", header[1])
+	}
+	comments, err := strconv.Atoi(header[5])
+	if err != nil || comments < 0 {
+		return nil, fmt.Errorf("invalid FIGlet comment line count %q", header[5])
+	}
+	font := &FigletFont{
+		height:      height,
+		hardblank:   header[0][len("flf2a"):][:1],
+		rightToLeft: len(header) > 6 && header[6] == "1",
+		glyphs:      map[rune][]string{},
+	}
+	for i := 0; i < comments; i++ {
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("font ends inside its comment")
+		}
+	}
+
+	readGlyph := func() ([]string, error) {
+		lines := make([]string, height)
+		for i := range lines {
+			if !scanner.Scan() {
+				return nil, io.ErrUnexpectedEOF
+			}
+			lines[i] = trimEndmark(scanner.Text())
+		}
+		return lines, nil
+	}
+	for code := ' '; code <= '~'; code++ {
+		glyph, err := readGlyph()
+		if err != nil {
+			return nil, fmt.Errorf("reading character %q: %w", code, err)
+		}
+		font.glyphs[code] = glyph
+	}
+	// some fonts omit the German characters, so from here on the font may end
+	for _, code := range germanCharacters {
+		glyph, err := readGlyph()
+		if err != nil {
+			return font, nil
+		}
+		font.glyphs[code] = glyph
+	}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid character code %q", fields[0])
+		}
+		glyph, err := readGlyph()
+		if err != nil {
+			return nil, fmt.Errorf("reading character %d: %w", code, err)
+		}
+		// negative codes are reserved for translation tables and never rendered
+		if code >= 0 {
+			font.glyphs[rune(code)] = glyph
+		}
+	}
+	return font, scanner.Err()
+}
+
+// trimEndmark removes the endmark, the last character repeated at the end of
+// every line of a glyph
+func trimEndmark(line string) string {
+	line = strings.TrimRight(line, "\r\n ")
+	if line == "" {
+		return line
+	}
+	return strings.TrimRight(line, line[len(line)-1:])
+}
+
+// LoadFigletFont parses the font name from fsys. The extension .flf may be
+// omitted.
+func LoadFigletFont(fsys fs.FS, name string) (*FigletFont, error) {
+	if !strings.HasSuffix(name, ".flf") {
+		name += ".flf"
+	}
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	font, err := ParseFigletFont(file)
+	if err != nil {
+		return nil, fmt.Errorf("parsing font %s: %w", name, err)
+	}
+	return font, nil
+}
+
+// BundledFont loads one of the fonts shipped with animaterm, see BundledFonts
+func BundledFont(name string) (*FigletFont, error) {
+	return LoadFigletFont(bundledFonts, path.Join("fonts", name))
+}
+
+// BundledFonts returns the names of the fonts shipped with animaterm
+func BundledFonts() []string {
+	entries, _ := bundledFonts.ReadDir("fonts")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".flf"))
+	}
+	return names
+}
+
+// Height returns the number of rows of a single line of text
+func (f *FigletFont) Height() int {
+	return f.height
+}
+
+// Render returns text in big letters together with its width and height in
+// cells. Every line of text becomes Height rows, all rows are padded to the
+// same width. Characters missing from the font are skipped.
+func (f *FigletFont) Render(text string) (string, int, int) {
+	rows := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		runes := []rune(line)
+		if f.rightToLeft {
+			slices.Reverse(runes)
+		}
+		block := make([]string, f.height)
+		for _, r := range runes {
+			glyph, ok := f.glyphs[r]
+			if !ok {
+				continue
+			}
+			glyphWidth := 0
+			for _, glyphLine := range glyph {
+				glyphWidth = max(glyphWidth, displayWidth(glyphLine))
+			}
+			for i, glyphLine := range glyph {
+				block[i] += glyphLine + strings.Repeat(" ", glyphWidth-displayWidth(glyphLine))
+			}
+		}
+		rows = append(rows, block...)
+	}
+	width := 0
+	for i, row := range rows {
+		rows[i] = strings.ReplaceAll(row, f.hardblank, " ")
+		width = max(width, displayWidth(rows[i]))
+	}
+	for i, row := range rows {
+		rows[i] = row + strings.Repeat(" ", width-displayWidth(row))
+	}
+	return strings.Join(rows, "\n"), width, len(rows)
+}
@@ -0,0 +1,110 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// tinyFont builds a font of height 2 in which every ASCII character is drawn
+// as a two cell wide box, except A and B which get glyphs of their own
+func tinyFont(extra string) string {
+	var sb strings.Builder
+	sb.WriteString("flf2a$ 2 1 4 0 1\ncomment\n")
+	for code := ' '; code <= '~'; code++ {
+		switch code {
+		case 'A':
+			sb.WriteString("/\\@\n||@@\n")
+		case 'B':
+			sb.WriteString("B$#\nB$##\n")
+		default:
+			sb.WriteString("..@\n..@@\n")
+		}
+	}
+	sb.WriteString(extra)
+	return sb.String()
+}
+
+func TestParseFigletFont(t *testing.T) {
+	tests := []struct {
+		name    string
+		font    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{name: "glyphs and hardblank", font: tinyFont(""), text: "AB", want: "/\\B \n||B "},
+		{name: "multiple lines", font: tinyFont(""), text: "A\nA", want: "/\\\n||\n/\\\n||"},
+		{name: "missing characters are skipped", font: tinyFont(""), text: "A€", want: "/\\\n||"},
+		{
+			name: "code tagged character",
+			font: tinyFont(strings.Repeat("??@\n??@@\n", 7) + "0x20AC  EURO SIGN\nEUR@\n€€€@@\n-1 negative codes are ignored\nx@\nx@@\n"),
+			text: "€", want: "EUR\n€€€",
+		},
+		{name: "not a font", font: "hello\n", wantErr: true},
+		{name: "invalid height", font: "flf2a$ x 1 4 0 0\n", wantErr: true},
+		{name: "truncated", font: "flf2a$ 2 1 4 0 0\n@\n@@\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := ParseFigletFont(strings.NewReader(tt.font))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFigletFont() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got, _, _ := font.Render(tt.text); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFigletFont(t *testing.T) {
+	fsys := fstest.MapFS{"fonts/tiny.flf": {Data: []byte(tinyFont(""))}}
+	font, err := LoadFigletFont(fsys, "fonts/tiny")
+	if err != nil {
+		t.Fatalf("LoadFigletFont() error = %v", err)
+	}
+	if font.Height() != 2 {
+		t.Errorf("Height() = %d, want 2", font.Height())
+	}
+	if _, err := LoadFigletFont(fsys, "missing"); err == nil {
+		t.Error("LoadFigletFont() of a missing font should return an error")
+	}
+}
+
+func TestBundledFonts(t *testing.T) {
+	names := BundledFonts()
+	if len(names) == 0 {
+		t.Fatal("no bundled fonts")
+	}
+	for _, name := range names {
+		font, err := BundledFont(name)
+		if err != nil {
+			t.Errorf("BundledFont(%q) error = %v", name, err)
+			continue
+		}
+		text, width, height := font.Render("Hi!")
+		if height != font.Height() || width == 0 {
+			t.Errorf("%s: size %dx%d for a single line", name, width, height)
+		}
+		if w, h := measureText(text); w != width || h != height {
+			t.Errorf("%s: measured %dx%d, returned %dx%d", name, w, h, width, height)
+		}
+	}
+
+	standard, _ := BundledFont("standard")
+	want := strings.Join([]string{
+		"  _   _   _ ",
+		" | | | | (_)",
+		" | |_| | | |",
+		" |  _  | | |",
+		" |_| |_| |_|",
+		"            ",
+	}, "\n")
+	if got, _, _ := standard.Render("Hi"); got != want {
+		t.Errorf("standard Render(\"Hi\") =\n%s\nwant\n%s", got, want)
+	}
+}
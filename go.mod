@@ -2,10 +2,7 @@ module github.com/gregod-com/animaterm
 
 go 1.24
 
-require (
-	github.com/common-nighthawk/go-figure v0.0.0-20210622060536-734e95fb86be
-	golang.org/x/mobile v0.0.0-20250606033058-a2a15c67f36f
-)
+require golang.org/x/mobile v0.0.0-20250606033058-a2a15c67f36f
 
 require (
 	golang.org/x/sys v0.33.0 // indirect
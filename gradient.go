@@ -0,0 +1,149 @@
+package animaterm
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GradientDirection selects how a gradient runs across an element's bounding box
+type GradientDirection int
+
+// GradientHorizontal ...
+const (
+	GradientHorizontal GradientDirection = iota
+	GradientVertical
+	GradientDiagonal
+	GradientRadial
+)
+
+// GradientStop is a color at a position (0-1) of a gradient
+type GradientStop struct {
+	Position float64
+	Color    RGB
+}
+
+// Gradient interpolates between any number of color stops in OKLab.
+// Offset shifts the gradient along its direction, positions moved beyond the
+// end wrap around. Speed is the number of gradient lengths the gradient
+// moves per second while it is animated, which makes it shimmer across the
+// element; gradients whose first and last stop match loop seamlessly.
+// Stops may be in any order; CreateGradientStops sorts them once, which saves
+// At from sorting a copy on every call.
+type Gradient struct {
+	Stops     []GradientStop
+	Direction GradientDirection
+	Offset    float64
+	Speed     float64
+}
+
+// CreateGradient creates a gradient with the colors spread evenly from start to end
+func CreateGradient(direction GradientDirection, colors ...RGB) Gradient {
+	stops := make([]GradientStop, len(colors))
+	for i, color := range colors {
+		stops[i] = GradientStop{Color: color}
+		if len(colors) > 1 {
+			stops[i].Position = float64(i) / float64(len(colors)-1)
+		}
+	}
+	return Gradient{Stops: stops, Direction: direction}
+}
+
+// CreateGradientStops creates a gradient from stops at arbitrary positions,
+// which are sorted by their position
+func CreateGradientStops(direction GradientDirection, stops ...GradientStop) Gradient {
+	stops = slices.Clone(stops)
+	slices.SortStableFunc(stops, compareStops)
+	return Gradient{Stops: stops, Direction: direction}
+}
+
+func compareStops(a GradientStop, b GradientStop) int {
+	return cmp.Compare(a.Position, b.Position)
+}
+
+// At returns the color at position t (0-1) of the gradient
+func (g Gradient) At(t float64) RGB {
+	if len(g.Stops) == 0 {
+		return RGB{}
+	}
+	t += g.Offset
+	if t < 0 || t > 1 {
+		t -= math.Floor(t)
+	}
+	stops := g.Stops
+	if !slices.IsSortedFunc(stops, compareStops) {
+		// a literal with stops out of order, sort a copy
+		stops = slices.Clone(stops)
+		slices.SortStableFunc(stops, compareStops)
+	}
+	if t <= stops[0].Position {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].Position {
+			from, to := stops[i-1], stops[i]
+			return from.Color.Lerp(to.Color, (t-from.Position)/(to.Position-from.Position))
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+// Shifted returns a copy of the gradient moved by offset gradient lengths
+func (g Gradient) Shifted(offset float64) Gradient {
+	g.Offset += offset
+	return g
+}
+
+// Elapsed returns the gradient as it has moved with its Speed after elapsed time
+func (g Gradient) Elapsed(elapsed time.Duration) Gradient {
+	return g.Shifted(g.Speed * elapsed.Seconds())
+}
+
+// in returns the color at the relative position fx and fy (0-1) of a bounding box
+func (g Gradient) in(fx float64, fy float64) RGB {
+	switch g.Direction {
+	case GradientVertical:
+		return g.At(fy)
+	case GradientDiagonal:
+		return g.At((fx + fy) / 2)
+	case GradientRadial:
+		return g.At(math.Hypot(fx-0.5, fy-0.5) / math.Hypot(0.5, 0.5))
+	default:
+		return g.At(fx)
+	}
+}
+
+// Apply colors every cell of text along the gradient, spread across the
+// bounding box of text. Existing escape sequences are removed. The result is
+// meant to be drawn with ALREADYCOLORED.
+func (g Gradient) Apply(text string) string {
+	width, height := measureText(text)
+	lines := getLines(stripANSI(text), false)
+	var sb strings.Builder
+	for k, line := range lines {
+		if k > 0 {
+			sb.WriteString("\n")
+		}
+		x := 0
+		for _, cluster := range graphemes(line) {
+			if cluster == " " {
+				sb.WriteString(cluster)
+			} else {
+				color := g.in(fraction(x, width), fraction(k, height)).Palette()
+				sb.WriteString(Style{Fg: color, Bg: DEFAULTCOLOR}.Render(cluster))
+			}
+			x += clusterWidth(cluster)
+		}
+	}
+	return sb.String()
+}
+
+// fraction returns the relative position of cell i within extent cells
+func fraction(i int, extent int) float64 {
+	if extent < 2 {
+		return 0
+	}
+	return float64(i) / float64(extent-1)
+}
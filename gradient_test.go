@@ -0,0 +1,93 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGradientAt(t *testing.T) {
+	red, green, blue := RGB{255, 0, 0}, RGB{0, 255, 0}, RGB{0, 0, 255}
+	tests := []struct {
+		name     string
+		gradient Gradient
+		t        float64
+		want     RGB
+	}{
+		{name: "start", gradient: CreateGradient(GradientHorizontal, red, green, blue), t: 0, want: red},
+		{name: "middle stop", gradient: CreateGradient(GradientHorizontal, red, green, blue), t: 0.5, want: green},
+		{name: "end", gradient: CreateGradient(GradientHorizontal, red, green, blue), t: 1, want: blue},
+		{
+			name:     "unsorted stops and clamping before the first stop",
+			gradient: CreateGradientStops(GradientHorizontal, GradientStop{Position: 0.8, Color: blue}, GradientStop{Position: 0.2, Color: red}),
+			t:        0.1,
+			want:     red,
+		},
+		{
+			name:     "unsorted literal",
+			gradient: Gradient{Stops: []GradientStop{{Position: 1, Color: blue}, {Position: 0, Color: red}}},
+			t:        0.25,
+			want:     red.Lerp(blue, 0.25),
+		},
+		{name: "offset wraps around", gradient: CreateGradient(GradientHorizontal, red, blue).Shifted(0.75), t: 0.5, want: red.Lerp(blue, 0.25)},
+		{name: "single stop", gradient: CreateGradient(GradientRadial, green), t: 0.3, want: green},
+		{name: "no stops", gradient: Gradient{}, t: 0.3, want: RGB{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.gradient.At(tt.t); got != tt.want {
+				t.Errorf("At(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGradientAtDoesNotAllocate(t *testing.T) {
+	gradient := CreateGradient(GradientHorizontal, RGB{255, 0, 0}, RGB{0, 255, 0}, RGB{0, 0, 255})
+	if allocs := testing.AllocsPerRun(100, func() { gradient.At(0.3) }); allocs != 0 {
+		t.Errorf("At allocates %v times per call", allocs)
+	}
+}
+
+func TestGradientElapsed(t *testing.T) {
+	gradient := CreateGradient(GradientHorizontal, RGB{255, 0, 0}, RGB{0, 0, 255})
+	gradient.Speed = 0.5
+	if got := gradient.Elapsed(time.Second).Offset; got != 0.5 {
+		t.Errorf("Offset after a second = %v, want 0.5", got)
+	}
+}
+
+func TestGradientApply(t *testing.T) {
+	black, white := RGB{0, 0, 0}, RGB{255, 255, 255}
+	tests := []struct {
+		name      string
+		direction GradientDirection
+		text      string
+		// palette colors of the first and last cell
+		first int
+		last  int
+	}{
+		{name: "horizontal", direction: GradientHorizontal, text: "abc", first: 16, last: 231},
+		{name: "vertical", direction: GradientVertical, text: "a\nb\nc", first: 16, last: 231},
+		{name: "diagonal", direction: GradientDiagonal, text: "ab\ncd", first: 16, last: 231},
+		{name: "radial", direction: GradientRadial, text: "abc\ndef\nghi", first: 231, last: 231},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := newANSIParser()
+			clusters := []styledCluster{}
+			for _, line := range strings.Split(CreateGradient(tt.direction, black, white).Apply(tt.text), "\n") {
+				clusters = append(clusters, parser.parse(line)...)
+			}
+			if got := clusters[0].style.Fg; got != tt.first {
+				t.Errorf("first cell color = %d, want %d", got, tt.first)
+			}
+			if got := clusters[len(clusters)-1].style.Fg; got != tt.last {
+				t.Errorf("last cell color = %d, want %d", got, tt.last)
+			}
+			if got := stripANSI(CreateGradient(tt.direction, black, white).Apply(tt.text)); got != tt.text {
+				t.Errorf("Apply changed the text to %q", got)
+			}
+		})
+	}
+}
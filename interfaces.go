@@ -1,7 +1,9 @@
 package animaterm
 
 import (
+	"io"
 	"sync"
+	"time"
 )
 
 // IUserInterface provides the main interface for terminal-based animations and UI rendering.
@@ -17,16 +19,63 @@ type IUserInterface interface {
 	SetBorder(percent int) error
 	// 	ClearScreen rest screen
 	ClearScreen() error
+	// Output returns the writer everything is rendered to, os.Stdout by default
+	Output() io.Writer
+	// SetOutput redirects rendering to w, e.g. to tee it into a recording
+	SetOutput(w io.Writer)
+	// Size returns the width and height of the pixel buffer in cells
+	Size() (int, int)
 	// StartDrawLoop initiates a loop, that renders the animations in a fixed loop
 	// the returning channel must be closed (i.e close(ch)) in order to stop the
 	// rendering; to allow the goroutine to stop all work the wg.Wait() command shopuld be used after
 	// sending the stop signal
 	StartDrawLoop(percentHeight int) (chan int, *sync.WaitGroup)
+	// OnResize registers a callback the draw loop invokes after the terminal
+	// was resized and the (now empty) pixel buffer adapted to the new size.
+	// The returned function removes the callback.
+	OnResize(callback func(width int, height int)) func()
+	// AddTicker registers a ticker the draw loop advances once per frame
+	AddTicker(ticker ITicker)
 	DrawElement(pos IRelativePosition, text string, color int) int
 	DrawElementsHorizontal(pos IRelativePosition, texts []string, positions []int, colors []int) int
 	DrawTable(pos IRelativePosition, table [][]string, positions []int, colors []int) int
+	// DrawTableScrolled draws visibleRows rows of table starting at row offset,
+	// like DrawTable, and a scrollbar in the last column of the frame when not
+	// all rows fit. The offset is clamped so the view never scrolls past the
+	// last row.
+	DrawTableScrolled(pos IRelativePosition, table [][]string, positions []int, colors []int, offset int, visibleRows int) int
+	// DrawText renders text wrapped, truncated and aligned to a maximum width
+	DrawText(pos IRelativePosition, text string, color int, opts TextOptions) int
+	// DrawClipped renders a single line of text at absolute cell coordinates,
+	// discarding every cell outside of clip
+	DrawClipped(clip Rect, x int, y int, text string, color int) int
+	// DrawBox draws the outline of rect with an optional title in its top edge
+	DrawBox(rect Rect, border BorderStyle, title string, color int) error
 	DrawPattern(startPos IRelativePosition, expansion int, text string, color int, animation Animation) int
 	MoveElement(startPos IRelativePosition, endPos IRelativePosition, text string, color int, animation Animation) error
+	// FadeIn draws text at pos with its foreground fading in from the
+	// background of the cells underneath. Cells without a background fade from
+	// the current theme's background. Blocks until the animation is complete.
+	FadeIn(pos IRelativePosition, text string, color int, animation Animation) error
+	// FadeOut fades the foreground of text at pos into its background and
+	// erases it afterwards. Blocks until the animation is complete.
+	FadeOut(pos IRelativePosition, text string, color int, animation Animation) error
+	// ColorTo redraws text at pos while its foreground and background change
+	// from one style to the other; attributes are those of to. Default colors
+	// stand for the current theme's background style. Blocks until the
+	// animation is complete.
+	ColorTo(pos IRelativePosition, text string, from Style, to Style, animation Animation) error
+	// Transition lets scene draw the next screen onto an offscreen user
+	// interface of the same size and animates from the current screen to it,
+	// see TransitionKind. The scene should only draw, widgets created on next
+	// stay bound to it. Blocks until the animation is complete.
+	Transition(scene func(next IUserInterface), kind TransitionKind, animation Animation) error
+
+	// Snapshot returns a copy of the pixels inside rect, cells outside of
+	// the screen are blank
+	Snapshot(rect Rect) [][]string
+	// Restore writes the pixels of a snapshot back with its top-left cell at x and y
+	Restore(x int, y int, snapshot [][]string)
 
 	// PercentToAbsoluteWidth returns the absolute width of percentage in frame (disregarding the absolute position)
 	PercentToAbsoluteWidth(percent int) int
@@ -36,11 +85,23 @@ type IUserInterface interface {
 	PercentToAbsoluteXPostion(percent int) int
 	// PercentToAbsoluteYPostion returns the absolute y coordinate of percentage in frame
 	PercentToAbsoluteYPostion(percent int) int
+	// PositionToAbsolute returns the absolute cell a position refers to in
+	// the frame, including its offset but disregarding its anchor
+	PositionToAbsolute(pos IRelativePosition) (int, int)
+}
+
+// ITicker is implemented by everything that animates on its own while the
+// draw loop is running, e.g. spinners.
+type ITicker interface {
+	// Tick is called once per frame with the current time. Returning false
+	// removes the ticker from the draw loop.
+	Tick(now time.Time) bool
 }
 
 // IRelativePosition represents a 2D position using percentage coordinates (0-100).
 // It supports coordinate transformations, distance calculations, and offset management
-// for multi-line text rendering.
+// for multi-line text rendering. The anchor decides which point of a drawn
+// element lands on the position; by default that is its top-left corner.
 type IRelativePosition interface {
 	// GetX returns x coodinate in percent
 	GetX() int
@@ -54,6 +115,9 @@ type IRelativePosition interface {
 	SetY(percenty int) error
 	// SetXandY see IRelativePosition
 	SetXandY(percentx int, percenty int) error
+	// Coords returns the exact x and y coordinates including fractions of a
+	// percent and absolute cell offsets
+	Coords() (Coord, Coord)
 	// IncrementOffset see IRelativePosition
 	IncrementOffset() IRelativePosition
 	// DistanceTo calculate distance between two points
@@ -63,9 +127,245 @@ type IRelativePosition interface {
 	// and returns a new position
 	AddDistance(p2 IRelativePosition) IRelativePosition
 	// MultiplyWith multiplies coordinates of point with factor
-	// and returns a new position with (rounded) x and y coordinates
+	// and returns a new position keeping fractions of a percent
 	MultiplyWith(factor float32) IRelativePosition
 	ResetOffset() IRelativePosition
 	GetOffset() int
 	SetOffset(offset int) IRelativePosition
+	// GetAnchor returns the point of an element's bounding box that is
+	// aligned to the position
+	GetAnchor() Anchor
+	// SetAnchor sets the point of an element's bounding box that is aligned
+	// to the position, e.g. AnchorCenter to center text on it
+	SetAnchor(anchor Anchor) IRelativePosition
+}
+
+// ILayoutNode is implemented by everything that can be placed by a layout
+type ILayoutNode interface {
+	// SetBounds moves the node into bounds and redraws it
+	SetBounds(bounds Rect)
+}
+
+// ILayout arranges its children in a row or column. Each child is sized with
+// fixed cells, a percentage or a weighted share of the remaining space.
+type ILayout interface {
+	ILayoutNode
+	// Add appends a child with the given size along the layout axis
+	Add(size Size, node ILayoutNode) ILayout
+	// SetPadding reserves cells between the bounds and the children
+	SetPadding(top int, right int, bottom int, left int) ILayout
+	// SetGap sets the number of cells between two children
+	SetGap(cells int) ILayout
+	// Mount lays the children out in the frame of ui and again whenever
+	// the terminal is resized
+	Mount(ui IUserInterface)
+	// Bounds returns the region the layout was last placed in
+	Bounds() Rect
+}
+
+// ILogPane is a region of the UserInterface that shows the tail of a bounded
+// history of log lines. Older lines can be brought back into view by scrolling.
+type ILogPane interface {
+	ILayoutNode
+	// Append adds a line made of colored segments and redraws the pane
+	Append(segments ...LogSegment)
+	// ScrollUp moves the view the given number of lines towards older entries
+	ScrollUp(lines int)
+	// ScrollDown moves the view the given number of lines towards newer entries
+	ScrollDown(lines int)
+	// ScrollToBottom makes the pane follow the newest lines again
+	ScrollToBottom()
+	// Lines returns the plain text of the buffered history, oldest first
+	Lines() []string
+	// Redraw renders the visible lines again, e.g. after the screen was cleared
+	Redraw()
+}
+
+// IProgressBar is a single row widget showing a label, a bar filling up from
+// left to right and optionally the percentage and estimated time remaining.
+type IProgressBar interface {
+	ILayoutNode
+	// SetProgress updates the progress (0-1) and redraws the bar
+	SetProgress(progress float64)
+	// Progress returns the current progress (0-1)
+	Progress() float64
+	// SetLabel replaces the text shown left of the bar. The label is drawn
+	// as is, pass it through Markup to style it with tags.
+	SetLabel(label string)
+	// Redraw renders the bar again, e.g. after the screen was cleared
+	Redraw()
+}
+
+// IMultiProgress stacks progress bars vertically. Bars may be added, updated
+// and completed concurrently from several goroutines.
+type IMultiProgress interface {
+	ILayoutNode
+	// AddBar appends a new bar below the existing ones
+	AddBar(label string) IProgressBar
+	// Complete fills the bar; if the container collapses finished bars it is
+	// removed and the bars below move up
+	Complete(bar IProgressBar)
+	// Bars returns the bars currently shown, top to bottom
+	Bars() []IProgressBar
+	// Redraw lays out and renders all bars again
+	Redraw()
+}
+
+// ISpinner is an animated activity indicator followed by a label. It is
+// advanced by the draw loop until it is finished with Success or Fail.
+type ISpinner interface {
+	ILayoutNode
+	// SetLabel replaces the text shown next to the spinner. The label is
+	// drawn as is, pass it through Markup to style it with tags.
+	SetLabel(label string)
+	// Success stops the spinner and replaces it with a check mark in the
+	// theme's success style; a non-empty message replaces the label
+	Success(message string)
+	// Fail stops the spinner and replaces it with a cross in the theme's
+	// error style; a non-empty message replaces the label
+	Fail(message string)
+}
+
+// IViewport is a rectangular drawing surface that clips everything drawn
+// into it to its bounds. Coordinates are relative to its top-left cell.
+type IViewport interface {
+	ILayoutNode
+	// Bounds returns the absolute region covered by the viewport
+	Bounds() Rect
+	// DrawElement renders multiline text at a percentage position inside the
+	// viewport and returns the row of the last rendered line
+	DrawElement(pos IRelativePosition, text string, color int) int
+	// DrawAt renders a single line of text at cell coordinates inside the
+	// viewport and returns the number of cells it advanced
+	DrawAt(x int, y int, text string, color int) int
+	// Clear blanks the whole viewport
+	Clear()
+}
+
+// IPanel is a framed region of the UserInterface. Its inside is available as
+// a viewport so content never overwrites the frame.
+type IPanel interface {
+	ILayoutNode
+	// SetTitle replaces the title shown in the top edge of the frame
+	SetTitle(title string)
+	// Content returns the area inside the frame. It is the same viewport on
+	// every call and follows the panel when it is moved.
+	Content() IViewport
+	// Redraw renders the frame again
+	Redraw()
+}
+
+// IScrollable is implemented by widgets showing a window of more rows than
+// fit into their bounds. Selection moves scroll the selected row into view,
+// so key handlers can map arrow and page keys onto MoveSelection.
+type IScrollable interface {
+	// ScrollTo makes row the first visible row, as far as possible
+	ScrollTo(row int)
+	// ScrollBy moves the view the given number of rows, negative values scroll up
+	ScrollBy(rows int)
+	// Offset returns the index of the first visible row
+	Offset() int
+	// MoveSelection moves the selection by delta rows and scrolls it into view.
+	// Without a selection moving down selects the first and moving up the last row.
+	MoveSelection(delta int)
+}
+
+// ITable is a table widget with a header, sized and aligned columns, and an
+// optionally highlighted selected row.
+type ITable interface {
+	ILayoutNode
+	IScrollable
+	// SetRows replaces all rows of the table
+	SetRows(rows [][]string)
+	// AddRow appends a row to the table
+	AddRow(cells ...string)
+	// Select highlights the row with the given index, -1 clears the selection
+	Select(row int)
+	// Selected returns the index of the selected row or -1
+	Selected() int
+	// Redraw renders the table again
+	Redraw()
+}
+
+// IList is a scrollable list of single line items with a selection
+type IList interface {
+	ILayoutNode
+	IScrollable
+	// SetItems replaces all items of the list
+	SetItems(items []string)
+	// AddItem appends an item to the list
+	AddItem(item string)
+	// Items returns a copy of the items of the list
+	Items() []string
+	// Select highlights the item with the given index and scrolls it into
+	// view, -1 clears the selection
+	Select(index int)
+	// Selected returns the index of the selected item or -1
+	Selected() int
+	// Redraw renders the list again
+	Redraw()
+}
+
+// ITextEffect reveals text with an effect while the draw loop is running,
+// see TextEffect
+type ITextEffect interface {
+	ITicker
+	// Done reports whether the whole text is revealed
+	Done() bool
+	// Skip reveals the whole text at once
+	Skip()
+}
+
+// IMarquee is a single row showing text that scrolls horizontally while it
+// does not fit, advanced by the draw loop
+type IMarquee interface {
+	ILayoutNode
+	// SetText replaces the text and starts scrolling it from the beginning
+	SetText(text string, color int)
+	// Stop halts the marquee and removes it from the draw loop
+	Stop()
+	// Redraw renders the visible part of the text again
+	Redraw()
+}
+
+// IParticleSystem animates particles spawned by emitters inside a region,
+// advanced by the draw loop while there is anything to animate
+type IParticleSystem interface {
+	ILayoutNode
+	// Emit places an emitter at pos, see Emitter
+	Emit(pos IRelativePosition, emitter Emitter)
+	// Count returns the number of living particles
+	Count() int
+	// Stop ends all emitters, living particles play out
+	Stop()
+	// Clear removes all emitters and particles at once
+	Clear()
+}
+
+// IRecorder records the output of a user interface as an asciicast v2
+type IRecorder interface {
+	// Stop ends the recording and gives the user interface its previous
+	// output back. Returns the first error writing the recording failed with.
+	Stop() error
+}
+
+// IPlayer plays a recorded terminal session inside a region
+type IPlayer interface {
+	ILayoutNode
+	// Pause stops the playback at the current position
+	Pause()
+	// Resume continues a paused playback
+	Resume()
+	// Paused reports whether the playback is paused
+	Paused() bool
+	// Seek jumps to position of the playback, clamped to its duration
+	Seek(position time.Duration)
+	// Position returns how far the playback got
+	Position() time.Duration
+	// Duration returns the length of the playback with idle time capped
+	Duration() time.Duration
+	// SetSpeed changes the playback speed, 2 plays twice as fast
+	SetSpeed(speed float64)
+	// Done reports whether all events have been played
+	Done() bool
 }
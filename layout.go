@@ -0,0 +1,242 @@
+package animaterm
+
+import (
+	"sync"
+)
+
+// SizeKind selects how a Size is resolved along the axis of a layout
+type SizeKind int
+
+// SizeFixed ...
+const (
+	// SizeFixed is an absolute number of cells
+	SizeFixed SizeKind = iota
+	// SizePercent is a percentage of the space available to all children
+	SizePercent
+	// SizeFill shares the remaining space with other fill children by weight
+	SizeFill
+	// SizeAuto fits the content, e.g. of a table column. Layout children have
+	// no content size, so layouts treat it like Fill(1).
+	SizeAuto
+)
+
+// Size is the extent of a layout child along the axis of its layout.
+// Min and Max constrain the resolved size in cells, a Max of 0 means unbounded.
+type Size struct {
+	Kind  SizeKind
+	Value int
+	Min   int
+	Max   int
+}
+
+// Fixed returns a size of exactly cells
+func Fixed(cells int) Size {
+	return Size{Kind: SizeFixed, Value: cells}
+}
+
+// Percent returns a size of percent of the available space
+func Percent(percent int) Size {
+	return Size{Kind: SizePercent, Value: percent}
+}
+
+// Fill returns a size taking a share of the remaining space proportional to weight
+func Fill(weight int) Size {
+	return Size{Kind: SizeFill, Value: weight}
+}
+
+// Auto returns a size fitting the content
+func Auto() Size {
+	return Size{Kind: SizeAuto}
+}
+
+// WithMin returns a copy of the size that never resolves below cells
+func (s Size) WithMin(cells int) Size {
+	s.Min = cells
+	return s
+}
+
+// WithMax returns a copy of the size that never resolves above cells
+func (s Size) WithMax(cells int) Size {
+	s.Max = cells
+	return s
+}
+
+func (s Size) clamp(cells int) int {
+	if s.Max > 0 && cells > s.Max {
+		cells = s.Max
+	}
+	if cells < s.Min {
+		cells = s.Min
+	}
+	return cells
+}
+
+type layoutChild struct {
+	size Size
+	node ILayoutNode
+}
+
+// Layout implements ILayout by stacking its children in a row or a column
+type Layout struct {
+	horizontal bool
+	children   []layoutChild
+	padding    [4]int
+	gap        int
+	bounds     Rect
+	mutex      sync.Mutex
+}
+
+// CreateRow creates a layout placing its children from left to right
+func CreateRow() ILayout {
+	return &Layout{horizontal: true}
+}
+
+// CreateColumn creates a layout placing its children from top to bottom
+func CreateColumn() ILayout {
+	return &Layout{}
+}
+
+// Add see ILayout
+func (l *Layout) Add(size Size, node ILayoutNode) ILayout {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.children = append(l.children, layoutChild{size: size, node: node})
+	return l
+}
+
+// SetPadding see ILayout
+func (l *Layout) SetPadding(top int, right int, bottom int, left int) ILayout {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.padding = [4]int{top, right, bottom, left}
+	return l
+}
+
+// SetGap see ILayout
+func (l *Layout) SetGap(cells int) ILayout {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.gap = cells
+	return l
+}
+
+// Mount see ILayout
+func (l *Layout) Mount(ui IUserInterface) {
+	frame := func() Rect {
+		return percentRect(ui, CreatePos(0, 0), 100, 100)
+	}
+	ui.OnResize(func(int, int) {
+		l.SetBounds(frame())
+	})
+	l.SetBounds(frame())
+}
+
+// SetBounds see ILayoutNode
+func (l *Layout) SetBounds(bounds Rect) {
+	l.mutex.Lock()
+	l.bounds = bounds
+	children := append([]layoutChild{}, l.children...)
+	rects := l.compute()
+	l.mutex.Unlock()
+
+	for i, child := range children {
+		child.node.SetBounds(rects[i])
+	}
+}
+
+// Bounds see ILayout
+func (l *Layout) Bounds() Rect {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.bounds
+}
+
+// compute resolves the region of every child. The caller must hold the mutex.
+func (l *Layout) compute() []Rect {
+	inner := Rect{
+		X:      l.bounds.X + l.padding[3],
+		Y:      l.bounds.Y + l.padding[0],
+		Width:  max(l.bounds.Width-l.padding[1]-l.padding[3], 0),
+		Height: max(l.bounds.Height-l.padding[0]-l.padding[2], 0),
+	}
+	available := inner.Height
+	if l.horizontal {
+		available = inner.Width
+	}
+	if len(l.children) > 1 {
+		available -= l.gap * (len(l.children) - 1)
+	}
+	sizes := resolveSizes(l.children, max(available, 0))
+
+	rects := make([]Rect, len(l.children))
+	offset := 0
+	for i, size := range sizes {
+		if l.horizontal {
+			rects[i] = Rect{X: inner.X + offset, Y: inner.Y, Width: size, Height: inner.Height}
+		} else {
+			rects[i] = Rect{X: inner.X, Y: inner.Y + offset, Width: inner.Width, Height: size}
+		}
+		offset += size + l.gap
+	}
+	return rects
+}
+
+// resolveSizes distributes available cells among children. Fixed and percent
+// sizes are resolved first, fill children then share what is left by weight.
+// A fill child hitting its min or max is frozen at that bound and the rest is
+// distributed again among the others.
+func resolveSizes(children []layoutChild, available int) []int {
+	sizes := make([]int, len(children))
+	remaining := available
+	fills := []int{}
+	for i, child := range children {
+		switch child.size.Kind {
+		case SizeFixed:
+			sizes[i] = child.size.clamp(child.size.Value)
+		case SizePercent:
+			sizes[i] = child.size.clamp(available * child.size.Value / 100)
+		default:
+			fills = append(fills, i)
+			continue
+		}
+		remaining -= sizes[i]
+	}
+
+	for len(fills) > 0 {
+		weights := 0
+		for _, i := range fills {
+			weights += max(children[i].size.Value, 1)
+		}
+		share := max(remaining, 0)
+		frozen := []int{}
+		unfrozen := []int{}
+		for _, i := range fills {
+			wanted := share * max(children[i].size.Value, 1) / weights
+			if clamped := children[i].size.clamp(wanted); clamped != wanted {
+				sizes[i] = clamped
+				remaining -= clamped
+				frozen = append(frozen, i)
+			} else {
+				unfrozen = append(unfrozen, i)
+			}
+		}
+		if len(frozen) > 0 {
+			fills = unfrozen
+			continue
+		}
+		// hand out the cells lost to integer division from the front
+		rest := share
+		for _, i := range fills {
+			sizes[i] = share * max(children[i].size.Value, 1) / weights
+			rest -= sizes[i]
+		}
+		for _, i := range fills {
+			if rest > 0 && children[i].size.clamp(sizes[i]+1) == sizes[i]+1 {
+				sizes[i]++
+				rest--
+			}
+		}
+		break
+	}
+	return sizes
+}
@@ -0,0 +1,82 @@
+package animaterm
+
+import (
+	"testing"
+)
+
+func TestResolveSizes(t *testing.T) {
+	tests := []struct {
+		name      string
+		sizes     []Size
+		available int
+		expected  []int
+	}{
+		{"Fixed and fill", []Size{Fixed(10), Fill(1)}, 100, []int{10, 90}},
+		{"Percent", []Size{Percent(25), Percent(50), Fill(1)}, 80, []int{20, 40, 20}},
+		{"Weighted fill", []Size{Fill(1), Fill(3)}, 80, []int{20, 60}},
+		{"Rounding remainder", []Size{Fill(1), Fill(1), Fill(1)}, 10, []int{4, 3, 3}},
+		{"Fill max", []Size{Fill(1).WithMax(10), Fill(1)}, 100, []int{10, 90}},
+		{"Fill min", []Size{Fill(1).WithMin(30), Fill(3)}, 80, []int{30, 50}},
+		{"Overflow", []Size{Fixed(60), Fixed(60), Fill(1)}, 100, []int{60, 60, 0}},
+		{"Clamped percent", []Size{Percent(90).WithMax(50), Fill(1)}, 100, []int{50, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			children := make([]layoutChild, len(tt.sizes))
+			for i, size := range tt.sizes {
+				children[i] = layoutChild{size: size}
+			}
+			result := resolveSizes(children, tt.available)
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Errorf("resolveSizes() = %v, want %v", result, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLayoutNesting(t *testing.T) {
+	header := &Viewport{}
+	left := &Viewport{}
+	right := &Viewport{}
+
+	root := CreateColumn().
+		SetPadding(1, 2, 1, 2).
+		SetGap(1).
+		Add(Fixed(3), header).
+		Add(Fill(1), CreateRow().SetGap(2).Add(Percent(25), left).Add(Fill(1), right))
+	root.SetBounds(Rect{X: 0, Y: 0, Width: 84, Height: 24})
+
+	expected := map[string][2]Rect{
+		"header": {header.Bounds(), {X: 2, Y: 1, Width: 80, Height: 3}},
+		"left":   {left.Bounds(), {X: 2, Y: 5, Width: 19, Height: 18}},
+		"right":  {right.Bounds(), {X: 23, Y: 5, Width: 59, Height: 18}},
+	}
+	for name, rects := range expected {
+		if rects[0] != rects[1] {
+			t.Errorf("%s bounds = %+v, want %+v", name, rects[0], rects[1])
+		}
+	}
+}
+
+func TestLayoutMount(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	content := &Viewport{}
+	CreateRow().Add(Fill(1), content).Mount(ui)
+
+	frame := Rect{X: 0, Y: 0, Width: Width(), Height: Height()}
+	if content.Bounds() != frame {
+		t.Errorf("mounted child bounds = %+v, want %+v", content.Bounds(), frame)
+	}
+
+	// shrinking the buffer makes the next resize check lay out again
+	content.SetBounds(Rect{})
+	_ = ui.initPixels(5, 5)
+	ui.checkResize()
+	if content.Bounds() != frame {
+		t.Errorf("child bounds after resize = %+v, want %+v", content.Bounds(), frame)
+	}
+}
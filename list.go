@@ -0,0 +1,171 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+)
+
+// ListStyle configures the look of a list. Marker is drawn in front of the
+// selected item, all other items are indented by its width.
+type ListStyle struct {
+	ItemStyle     Style
+	SelectedStyle Style
+	Marker        string
+}
+
+// DefaultListStyle marks and reverses the selected item
+var DefaultListStyle = ListStyle{
+	ItemStyle:     DefaultStyle,
+	SelectedStyle: Style{Fg: DEFAULTCOLOR, Bg: DEFAULTCOLOR, Reverse: true},
+	Marker:        "> ",
+}
+
+// List implements IList
+type List struct {
+	ui       IUserInterface
+	bounds   Rect
+	items    []string
+	style    ListStyle
+	selected int
+	offset   int
+	mutex    sync.Mutex
+}
+
+// CreateList creates a list at pos spanning percentWidth and percentHeight of
+// the frame. When there are more items than rows it scrolls and shows a
+// scrollbar in its rightmost column.
+func CreateList(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int, style ListStyle) IList {
+	list := &List{
+		ui:       ui,
+		bounds:   percentRect(ui, pos, percentWidth, percentHeight),
+		style:    style,
+		selected: -1,
+	}
+	list.Redraw()
+	return list
+}
+
+// SetItems see IList
+func (l *List) SetItems(items []string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.items = items
+	if l.selected >= len(items) {
+		l.selected = -1
+	}
+	l.offset = clampOffset(l.offset, len(l.items), l.bounds.Height)
+	l.draw()
+}
+
+// AddItem see IList
+func (l *List) AddItem(item string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.items = append(l.items, item)
+	l.draw()
+}
+
+// Items see IList
+func (l *List) Items() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]string(nil), l.items...)
+}
+
+// Select see IList
+func (l *List) Select(index int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if index < 0 || index >= len(l.items) {
+		index = -1
+	}
+	l.selected = index
+	l.offset = revealOffset(index, l.offset, l.bounds.Height)
+	l.draw()
+}
+
+// Selected see IList
+func (l *List) Selected() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.selected
+}
+
+// MoveSelection see IScrollable
+func (l *List) MoveSelection(delta int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.selected = moveSelection(l.selected, delta, len(l.items))
+	l.offset = revealOffset(l.selected, l.offset, l.bounds.Height)
+	l.draw()
+}
+
+// ScrollTo see IScrollable
+func (l *List) ScrollTo(row int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.offset = clampOffset(row, len(l.items), l.bounds.Height)
+	l.draw()
+}
+
+// ScrollBy see IScrollable
+func (l *List) ScrollBy(rows int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.offset = clampOffset(l.offset+rows, len(l.items), l.bounds.Height)
+	l.draw()
+}
+
+// Offset see IScrollable
+func (l *List) Offset() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.offset
+}
+
+// SetBounds see ILayoutNode
+func (l *List) SetBounds(bounds Rect) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.bounds = bounds
+	l.offset = clampOffset(l.offset, len(l.items), l.bounds.Height)
+	l.draw()
+}
+
+// Redraw see IList
+func (l *List) Redraw() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.draw()
+}
+
+// draw renders the visible items and blanks the rest of the bounds. The
+// caller must hold the mutex.
+func (l *List) draw() {
+	scrolled := len(l.items) > l.bounds.Height
+	width := l.bounds.Width
+	if scrolled {
+		width--
+	}
+	indent := strings.Repeat(" ", displayWidth(l.style.Marker))
+	blank := strings.Repeat(" ", l.bounds.Width)
+	for row := 0; row < l.bounds.Height; row++ {
+		y := l.bounds.Y + row
+		if i := l.offset + row; i < len(l.items) {
+			text, style := indent+l.items[i], l.style.ItemStyle
+			if i == l.selected {
+				text, style = l.style.Marker+l.items[i], l.style.SelectedStyle
+			}
+			text = alignLine(ellipsis(strings.ReplaceAll(text, "\n", " "), width), width, AlignLeft, true)
+			l.ui.DrawClipped(l.bounds, l.bounds.X, y, style.Render(text), ALREADYCOLORED)
+		} else {
+			l.ui.DrawClipped(l.bounds, l.bounds.X, y, blank, BLANK)
+		}
+	}
+	if scrolled {
+		x := l.bounds.X + l.bounds.Width - 1
+		for i, cell := range scrollbar(l.bounds.Height, len(l.items), l.offset) {
+			l.ui.DrawClipped(l.bounds, x, l.bounds.Y+i, cell, ALREADYCOLORED)
+		}
+	}
+}
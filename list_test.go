@@ -0,0 +1,82 @@
+package animaterm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestListScrolling(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	style := ListStyle{ItemStyle: DefaultStyle, SelectedStyle: DefaultStyle, Marker: "> "}
+	// 25% of 24 rows shows 6 items
+	list := CreateList(ui, CreatePos(0, 0), 25, 25, style)
+	items := []string{}
+	for i := 0; i < 100; i++ {
+		items = append(items, fmt.Sprintf("item %d", i))
+	}
+	list.SetItems(items)
+
+	if got := row(ui, 0, 0, 20); got != "  item 0           █" {
+		t.Errorf("first row = %q", got)
+	}
+	if got := row(ui, 5, 19, 1); got != ScrollbarTrack {
+		t.Errorf("scrollbar track = %q", got)
+	}
+
+	list.ScrollTo(1000)
+	if list.Offset() != 94 {
+		t.Errorf("Offset() after ScrollTo past the end = %d, want 94", list.Offset())
+	}
+	if got := row(ui, 5, 0, 10); got != "  item 99 " {
+		t.Errorf("last row = %q", got)
+	}
+	if got := row(ui, 5, 19, 1); got != ScrollbarThumb {
+		t.Errorf("scrollbar thumb at the end = %q", got)
+	}
+
+	list.ScrollBy(-200)
+	if list.Offset() != 0 {
+		t.Errorf("Offset() after scrolling up = %d, want 0", list.Offset())
+	}
+
+	list.Select(50)
+	if list.Offset() != 45 {
+		t.Errorf("Offset() after Select(50) = %d, want 45", list.Offset())
+	}
+	if got := row(ui, 5, 0, 10); got != "> item 50 " {
+		t.Errorf("selected row = %q", got)
+	}
+}
+
+func TestListMoveSelection(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	tests := []struct {
+		name     string
+		selected int
+		delta    int
+		want     int
+	}{
+		{name: "down without selection", selected: -1, delta: 1, want: 0},
+		{name: "up without selection", selected: -1, delta: -1, want: 9},
+		{name: "page down", selected: 2, delta: 5, want: 7},
+		{name: "stops at the end", selected: 8, delta: 5, want: 9},
+		{name: "stops at the start", selected: 1, delta: -5, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := CreateList(ui, CreatePos(0, 0), 25, 25, DefaultListStyle)
+			list.SetItems(strings.Split("a b c d e f g h i j", " "))
+			list.Select(tt.selected)
+			list.MoveSelection(tt.delta)
+			if got := list.Selected(); got != tt.want {
+				t.Errorf("Selected() = %d, want %d", got, tt.want)
+			}
+			if list.Offset() > tt.want || list.Offset()+6 <= tt.want {
+				t.Errorf("selection %d not visible at offset %d", tt.want, list.Offset())
+			}
+		})
+	}
+}
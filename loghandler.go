@@ -0,0 +1,138 @@
+package animaterm
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogHandlerOptions configures the slog.Handler created by CreateLogHandler
+type LogHandlerOptions struct {
+	// Level is the minimum level that is rendered, defaults to slog.LevelInfo
+	Level slog.Leveler
+	// TimeFormat is the layout of the timestamp column, defaults to "15:04:05.000"
+	TimeFormat string
+	// MessageWidth pads messages to a fixed number of cells so the attributes
+	// of consecutive lines start in the same column, defaults to 40
+	MessageWidth int
+}
+
+// LogHandler implements slog.Handler by rendering records into an ILogPane.
+// Levels are colored with the palette constants of this package.
+type LogHandler struct {
+	pane   ILogPane
+	opts   LogHandlerOptions
+	attrs  []LogSegment
+	prefix string
+}
+
+// CreateLogHandler creates a slog.Handler that appends every record as a
+// single line to pane. opts may be nil to use the defaults.
+func CreateLogHandler(pane ILogPane, opts *LogHandlerOptions) slog.Handler {
+	h := &LogHandler{pane: pane}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	if h.opts.TimeFormat == "" {
+		h.opts.TimeFormat = "15:04:05.000"
+	}
+	if h.opts.MessageWidth <= 0 {
+		h.opts.MessageWidth = 40
+	}
+	return h
+}
+
+// Enabled see slog.Handler
+func (h *LogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle see slog.Handler
+func (h *LogHandler) Handle(_ context.Context, record slog.Record) error {
+	segments := []LogSegment{}
+	if !record.Time.IsZero() {
+		segments = append(segments, LogSegment{record.Time.Format(h.opts.TimeFormat) + " ", COLORPATTERNGOINGGREY3})
+	}
+	segments = append(segments, LogSegment{fmt.Sprintf("%-5s ", record.Level.String()), levelColor(record.Level)})
+
+	msg := strings.ReplaceAll(record.Message, "\n", " ")
+	if pad := h.opts.MessageWidth - displayWidth(msg); pad > 0 {
+		msg += strings.Repeat(" ", pad)
+	}
+	segments = append(segments, LogSegment{msg, GREY})
+	segments = append(segments, h.attrs...)
+	record.Attrs(func(attr slog.Attr) bool {
+		segments = appendAttr(segments, h.prefix, attr)
+		return true
+	})
+	h.pane.Append(segments...)
+	return nil
+}
+
+// WithAttrs see slog.Handler
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = append([]LogSegment{}, h.attrs...)
+	for _, attr := range attrs {
+		h2.attrs = appendAttr(h2.attrs, h.prefix, attr)
+	}
+	return &h2
+}
+
+// WithGroup see slog.Handler
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// appendAttr renders attr as colored key=value segments, flattening groups
+// into dotted keys the same way slog.TextHandler does
+func appendAttr(segments []LogSegment, prefix string, attr slog.Attr) []LogSegment {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return segments
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			segments = appendAttr(segments, prefix, groupAttr)
+		}
+		return segments
+	}
+	value := attr.Value.String()
+	if attr.Value.Kind() == slog.KindTime {
+		value = attr.Value.Time().Format(time.RFC3339)
+	} else if value == "" || strings.ContainsAny(value, " =\"\n") {
+		value = strconv.Quote(value)
+	}
+	return append(segments,
+		LogSegment{" " + prefix + attr.Key + "=", COLORPATTERNSKYLIGHT},
+		LogSegment{value, WHITE},
+	)
+}
+
+// levelColor maps a slog level onto the palette
+func levelColor(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return RED2
+	case level >= slog.LevelWarn:
+		return ORANGE
+	case level >= slog.LevelInfo:
+		return COLORPATTERNLIME
+	default:
+		return COLORPATTERNBABYSTEPS1
+	}
+}
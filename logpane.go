@@ -0,0 +1,152 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+)
+
+// LogSegment is a piece of a log line rendered in a single color
+type LogSegment struct {
+	Text  string
+	Color int
+}
+
+// LogPane implements ILogPane as a ring buffer of lines rendered into a
+// fixed region of the UserInterface.
+type LogPane struct {
+	ui     IUserInterface
+	bounds Rect
+	lines  [][]LogSegment
+	start  int
+	count  int
+	scroll int
+	mutex  sync.Mutex
+}
+
+// CreateLogPane creates a new pane whose top-left corner is placed at pos and
+// which spans percentWidth and percentHeight of the frame. capacity limits the
+// number of lines kept in history; once reached the oldest lines are dropped.
+// A capacity below 1 falls back to 1000 lines.
+func CreateLogPane(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int, capacity int) ILogPane {
+	if capacity < 1 {
+		capacity = 1000
+	}
+	return &LogPane{
+		ui:     ui,
+		bounds: percentRect(ui, pos, percentWidth, percentHeight),
+		lines:  make([][]LogSegment, capacity),
+	}
+}
+
+// Append see ILogPane
+func (lp *LogPane) Append(segments ...LogSegment) {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+
+	if lp.count < len(lp.lines) {
+		lp.lines[(lp.start+lp.count)%len(lp.lines)] = segments
+		lp.count++
+	} else {
+		lp.lines[lp.start] = segments
+		lp.start = (lp.start + 1) % len(lp.lines)
+	}
+	// keep a scrolled back view pinned to the lines the user is looking at
+	if lp.scroll > 0 {
+		lp.scroll = lp.clampScroll(lp.scroll + 1)
+	}
+	lp.draw()
+}
+
+// ScrollUp see ILogPane
+func (lp *LogPane) ScrollUp(lines int) {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+	lp.scroll = lp.clampScroll(lp.scroll + lines)
+	lp.draw()
+}
+
+// ScrollDown see ILogPane
+func (lp *LogPane) ScrollDown(lines int) {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+	lp.scroll = lp.clampScroll(lp.scroll - lines)
+	lp.draw()
+}
+
+// ScrollToBottom see ILogPane
+func (lp *LogPane) ScrollToBottom() {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+	lp.scroll = 0
+	lp.draw()
+}
+
+// SetBounds see ILayoutNode
+func (lp *LogPane) SetBounds(bounds Rect) {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+	lp.bounds = bounds
+	lp.scroll = lp.clampScroll(lp.scroll)
+	lp.draw()
+}
+
+// Lines see ILogPane
+func (lp *LogPane) Lines() []string {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+
+	lines := make([]string, 0, lp.count)
+	for i := 0; i < lp.count; i++ {
+		lines = append(lines, joinSegments(lp.line(i)))
+	}
+	return lines
+}
+
+// Redraw see ILogPane
+func (lp *LogPane) Redraw() {
+	lp.mutex.Lock()
+	defer lp.mutex.Unlock()
+	lp.draw()
+}
+
+// line returns the i-th oldest line of the history
+func (lp *LogPane) line(i int) []LogSegment {
+	return lp.lines[(lp.start+i)%len(lp.lines)]
+}
+
+func (lp *LogPane) clampScroll(scroll int) int {
+	maxScroll := lp.count - lp.bounds.Height
+	if scroll > maxScroll {
+		scroll = maxScroll
+	}
+	if scroll < 0 {
+		scroll = 0
+	}
+	return scroll
+}
+
+// draw renders the visible window of the history, the newest visible line at
+// the bottom. Rows without content are blanked. The caller must hold the mutex.
+func (lp *LogPane) draw() {
+	first := lp.count - lp.scroll - lp.bounds.Height
+	for row := 0; row < lp.bounds.Height; row++ {
+		y := lp.bounds.Y + row
+		x := lp.bounds.X
+		if i := first + row; i >= 0 && i < lp.count {
+			for _, segment := range lp.line(i) {
+				x += lp.ui.DrawClipped(lp.bounds, x, y, segment.Text, segment.Color)
+			}
+		}
+		if rest := lp.bounds.X + lp.bounds.Width - x; rest > 0 {
+			lp.ui.DrawClipped(lp.bounds, x, y, strings.Repeat(" ", rest), BLANK)
+		}
+	}
+}
+
+func joinSegments(segments []LogSegment) string {
+	var sb strings.Builder
+	for _, segment := range segments {
+		sb.WriteString(segment.Text)
+	}
+	return sb.String()
+}
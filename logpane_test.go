@@ -0,0 +1,82 @@
+package animaterm
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogPaneRingBuffer(t *testing.T) {
+	ui := CreateUI()
+	pane := CreateLogPane(ui, CreatePos(0, 0), 50, 20, 3)
+
+	for _, text := range []string{"one", "two", "three", "four"} {
+		pane.Append(LogSegment{Text: text, Color: WHITE})
+	}
+
+	lines := pane.Lines()
+	expected := []string{"two", "three", "four"}
+	if len(lines) != len(expected) {
+		t.Fatalf("Lines() returned %d lines, want %d", len(lines), len(expected))
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Lines()[%d] = %q, want %q", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestLogPaneScroll(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	pos := CreatePos(0, 0)
+	pane := CreateLogPane(ui, pos, 50, 20, 100).(*LogPane)
+	bottom := pane.bounds.Y + pane.bounds.Height - 1
+
+	for i := 0; i < pane.bounds.Height+5; i++ {
+		pane.Append(LogSegment{Text: string(rune('a' + i)), Color: ALREADYCOLORED})
+	}
+	last := string(rune('a' + pane.bounds.Height + 4))
+
+	if ui.pixels[bottom][0] != last {
+		t.Errorf("bottom row = %q, want %q", ui.pixels[bottom][0], last)
+	}
+
+	pane.ScrollUp(2)
+	if ui.pixels[bottom][0] != string(rune('a'+pane.bounds.Height+2)) {
+		t.Errorf("bottom row after ScrollUp(2) = %q", ui.pixels[bottom][0])
+	}
+
+	// the view stays in place while new lines arrive
+	pane.Append(LogSegment{Text: "z", Color: ALREADYCOLORED})
+	if ui.pixels[bottom][0] != string(rune('a'+pane.bounds.Height+2)) {
+		t.Errorf("bottom row moved while scrolled back: %q", ui.pixels[bottom][0])
+	}
+
+	pane.ScrollUp(1000)
+	if pane.scroll != pane.count-pane.bounds.Height {
+		t.Errorf("scroll = %d, want it capped at %d", pane.scroll, pane.count-pane.bounds.Height)
+	}
+
+	pane.ScrollToBottom()
+	if ui.pixels[bottom][0] != "z" {
+		t.Errorf("bottom row after ScrollToBottom = %q, want %q", ui.pixels[bottom][0], "z")
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	ui := CreateUI()
+	pane := CreateLogPane(ui, CreatePos(0, 0), 100, 50, 10)
+	logger := slog.New(CreateLogHandler(pane, &LogHandlerOptions{MessageWidth: 10}))
+
+	logger.Debug("hidden")
+	logger.With("component", "build").WithGroup("job").Warn("done", "id", 7, "name", "a b")
+
+	lines := pane.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %q", len(lines), lines)
+	}
+	expected := "WARN  done       component=build job.id=7 job.name=\"a b\""
+	if !strings.HasSuffix(lines[0], expected) {
+		t.Errorf("line = %q, want suffix %q", lines[0], expected)
+	}
+}
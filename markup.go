@@ -0,0 +1,167 @@
+package animaterm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// colorNames maps the names usable in markup and style specs onto the
+// palette constants. The COLORPATTERN constants are also available without
+// their prefix, e.g. "lime" or "neon1".
+var colorNames = map[string]int{
+	"black":                       BLACK,
+	"red":                         RED,
+	"green":                       GREEN,
+	"yellow":                      YELLOW,
+	"blue":                        BLUE,
+	"violet":                      VIOLET,
+	"white":                       WHITE,
+	"grey":                        GREY,
+	"lightgrey":                   LIGHTGREY,
+	"darkblue":                    DARKBLUE,
+	"terminalgreen":               TERMINALGREEN,
+	"orange":                      ORANGE,
+	"red2":                        RED2,
+	"pink":                        PINK,
+	"colorpatternpastel":          COLORPATTERNPASTEL,
+	"colorpatternskylight":        COLORPATTERNSKYLIGHT,
+	"colorpatternmeadows1":        COLORPATTERNMEADOWS1,
+	"colorpatternmeadows2":        COLORPATTERNMEADOWS2,
+	"colorpatternneon1":           COLORPATTERNNEON1,
+	"colorpatternneon2":           COLORPATTERNNEON2,
+	"colorpatternneon3":           COLORPATTERNNEON3,
+	"colorpatterngreenfoundation": COLORPATTERNGREENFOUNDATION,
+	"colorpatternlime":            COLORPATTERNLIME,
+	"colorpatterngrey":            COLORPATTERNGREY,
+	"colorpatternsplitmeadows":    COLORPATTERNSPLITMEADOWS,
+	"colorpatternbabysteps1":      COLORPATTERNBABYSTEPS1,
+	"colorpatternbabysteps2":      COLORPATTERNBABYSTEPS2,
+	"colorpatterngoinggrey1":      COLORPATTERNGOINGGREY1,
+	"colorpatterngoinggrey2":      COLORPATTERNGOINGGREY2,
+	"colorpatterngoinggrey3":      COLORPATTERNGOINGGREY3,
+	"colorpatterngoinggrey4":      COLORPATTERNGOINGGREY4,
+	"colorpatterngoinggrey5":      COLORPATTERNGOINGGREY5,
+	"colorpatterngoinggrey6":      COLORPATTERNGOINGGREY6,
+	"default":                     DEFAULTCOLOR,
+}
+
+// lookupColor resolves a color name or a palette index (0-255)
+func lookupColor(name string) (int, bool) {
+	name = strings.ToLower(name)
+	if color, ok := colorNames[name]; ok {
+		return color, true
+	}
+	if color, ok := colorNames["colorpattern"+name]; ok {
+		return color, true
+	}
+	if index, err := strconv.Atoi(name); err == nil && index >= 0 && index < 256 {
+		return index, true
+	}
+	return 0, false
+}
+
+// ParseStyle parses a style spec such as "bold red on black". A spec is a
+// list of attributes (bold, dim, italic, underline, blink, reverse, strike),
+// a foreground color and a background color following "on". Colors are
+// names of the palette constants or palette indices. Role names of the
+// current theme like "success" apply the role's style, see Theme.
+func ParseStyle(spec string) (Style, error) {
+	return applyStyleSpec(DefaultStyle, spec)
+}
+
+// applyStyleSpec returns base modified by the words of spec
+func applyStyleSpec(base Style, spec string) (Style, error) {
+	words := strings.Fields(spec)
+	if len(words) == 0 {
+		return base, fmt.Errorf("empty style")
+	}
+	style := base
+	for i := 0; i < len(words); i++ {
+		switch word := strings.ToLower(words[i]); word {
+		case "bold":
+			style.Bold = true
+		case "dim":
+			style.Dim = true
+		case "italic":
+			style.Italic = true
+		case "underline":
+			style.Underline = true
+		case "blink":
+			style.Blink = true
+		case "reverse":
+			style.Reverse = true
+		case "strike":
+			style.Strike = true
+		case "on":
+			if i+1 == len(words) {
+				return base, fmt.Errorf("missing background color in style %q", spec)
+			}
+			i++
+			color, ok := lookupColor(words[i])
+			if !ok {
+				return base, fmt.Errorf("unknown color %q in style %q", words[i], spec)
+			}
+			style.Bg = color
+		default:
+			if role, ok := CurrentTheme().Role(word); ok {
+				style = overlay(style, role)
+				continue
+			}
+			color, ok := lookupColor(word)
+			if !ok {
+				return base, fmt.Errorf("unknown word %q in style %q", words[i], spec)
+			}
+			style.Fg = color
+		}
+	}
+	return style, nil
+}
+
+// Markup converts text containing style tags into text with escape
+// sequences. A tag like [bold red] applies a style spec (see ParseStyle) on
+// top of the enclosing one until the matching [/]. Brackets that do not form
+// a valid tag are kept, \[ produces a literal bracket and \\ a backslash.
+//
+//	Markup("[lime]OK[/] [bold red]FAIL[/]")
+func Markup(text string) string {
+	var out, run strings.Builder
+	stack := []Style{DefaultStyle}
+	flush := func() {
+		if run.Len() > 0 {
+			out.WriteString(stack[len(stack)-1].Render(run.String()))
+			run.Reset()
+		}
+	}
+	for i := 0; i < len(text); {
+		c := text[i]
+		if c == '\\' && i+1 < len(text) && (text[i+1] == '[' || text[i+1] == '\\') {
+			run.WriteByte(text[i+1])
+			i += 2
+			continue
+		}
+		if c == '[' {
+			if end := strings.IndexByte(text[i:], ']'); end > 0 {
+				tag := text[i+1 : i+end]
+				if tag == "/" {
+					flush()
+					if len(stack) > 1 {
+						stack = stack[:len(stack)-1]
+					}
+					i += end + 1
+					continue
+				}
+				if style, err := applyStyleSpec(stack[len(stack)-1], tag); err == nil {
+					flush()
+					stack = append(stack, style)
+					i += end + 1
+					continue
+				}
+			}
+		}
+		run.WriteByte(c)
+		i++
+	}
+	flush()
+	return out.String()
+}
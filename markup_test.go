@@ -0,0 +1,79 @@
+package animaterm
+
+import (
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Style
+		wantErr  bool
+	}{
+		{"red", Style{Fg: RED, Bg: DEFAULTCOLOR}, false},
+		{"bold lime on black", Style{Fg: COLORPATTERNLIME, Bg: BLACK, Bold: true}, false},
+		{"underline 202", Style{Fg: ORANGE, Bg: DEFAULTCOLOR, Underline: true}, false},
+		{"colorpatternneon1", Style{Fg: COLORPATTERNNEON1, Bg: DEFAULTCOLOR}, false},
+		{"", DefaultStyle, true},
+		{"red on", DefaultStyle, true},
+		{"purple", DefaultStyle, true},
+		{"300", DefaultStyle, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			style, err := ParseStyle(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStyle(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if style != tt.expected {
+				t.Errorf("ParseStyle(%q) = %+v, want %+v", tt.spec, style, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkup(t *testing.T) {
+	red := Style{Fg: RED, Bg: DEFAULTCOLOR}
+	boldRed := Style{Fg: RED, Bg: DEFAULTCOLOR, Bold: true}
+	lime := Style{Fg: COLORPATTERNLIME, Bg: DEFAULTCOLOR}
+
+	tests := []struct {
+		name     string
+		markup   string
+		expected string
+	}{
+		{"Plain", "plain", "plain"},
+		{"Runs", "[lime]OK[/] [bold red]FAIL[/]", lime.Render("OK") + " " + boldRed.Render("FAIL")},
+		{"Nested", "[red]a[bold]b[/]c[/]", red.Render("a") + boldRed.Render("b") + red.Render("c")},
+		{"Escaped", `\[red] \\`, `[red] \`},
+		{"Not a style", "[ OK ] [x", "[ OK ] [x"},
+		{"Unbalanced close", "a[/]b", "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Markup(tt.markup); result != tt.expected {
+				t.Errorf("Markup(%q) = %q, want %q", tt.markup, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDrawElementMarkup(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.SetBorder(0)
+
+	ui.DrawElement(CreatePos(0, 0), "[red]a\nb[/]c", MARKUP)
+	red := Style{Fg: RED, Bg: DEFAULTCOLOR}
+	if ui.pixels[0][0] != red.Render("a") || ui.pixels[1][0] != red.Render("b") || ui.pixels[1][1] != "c" {
+		t.Errorf("markup cells = %q %q %q", ui.pixels[0][0], ui.pixels[1][0], ui.pixels[1][1])
+	}
+
+	ui.DrawTable(CreatePos(0, 50), [][]string{{"[lime]ok[/]", "x"}}, []int{0, 50}, []int{MARKUP, MARKUP})
+	y := ui.PercentToAbsoluteYPostion(50)
+	lime := Style{Fg: COLORPATTERNLIME, Bg: DEFAULTCOLOR}
+	if ui.pixels[y][0] != lime.Render("o") || ui.pixels[y][2] != " " {
+		t.Errorf("markup table cells = %q %q", ui.pixels[y][0], ui.pixels[y][2])
+	}
+}
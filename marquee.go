@@ -0,0 +1,201 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarqueeMode selects how a marquee scrolls text that does not fit
+type MarqueeMode int
+
+// MarqueeLoop ...
+const (
+	// MarqueeLoop scrolls the text continuously, repeating it after Gap
+	MarqueeLoop MarqueeMode = iota
+	// MarqueeBounce scrolls to the end of the text and back again
+	MarqueeBounce
+)
+
+// MarqueeStyle configures a marquee. Speed is in cells per second (8 if
+// unset). The text scrolls to the left, or to the right with ScrollRight.
+// Pause is how long the text rests at its ends before scrolling on. Gap
+// separates the repetitions of a looping text (three spaces if unset).
+type MarqueeStyle struct {
+	Speed       float64
+	ScrollRight bool
+	Mode        MarqueeMode
+	Pause       time.Duration
+	Gap         string
+}
+
+// Marquee implements IMarquee and ITicker
+type Marquee struct {
+	ui      IUserInterface
+	bounds  Rect
+	style   MarqueeStyle
+	cells   []styledCluster
+	start   time.Time
+	offset  int
+	stopped bool
+	mutex   sync.Mutex
+}
+
+// CreateMarquee creates a single row marquee at pos spanning percentWidth of
+// the frame and registers it with the draw loop of ui. Text that fits is
+// shown as it is, longer text scrolls. Style tags are interpreted with
+// MARKUP and escape sequences with ALREADYCOLORED.
+func CreateMarquee(ui IUserInterface, pos IRelativePosition, percentWidth int, text string, color int, style MarqueeStyle) IMarquee {
+	if style.Speed <= 0 {
+		style.Speed = 8
+	}
+	if style.Gap == "" {
+		style.Gap = "   "
+	}
+	bounds := percentRect(ui, pos, percentWidth, 0)
+	bounds.Height = 1
+	m := &Marquee{
+		ui:     ui,
+		bounds: bounds,
+		style:  style,
+		start:  time.Now(),
+	}
+	m.cells = m.layout(text, color)
+	m.draw()
+	ui.AddTicker(m)
+	return m
+}
+
+// layout splits text into cells, wide clusters are followed by a continuation
+func (m *Marquee) layout(text string, color int) []styledCluster {
+	text, color = resolveMarkup(strings.ReplaceAll(text, "\n", " "), color)
+	if color != ALREADYCOLORED {
+		text = Color(text, color)
+	}
+	cells := []styledCluster{}
+	for _, cluster := range newANSIParser().parse(text) {
+		cells = append(cells, cluster)
+		if clusterWidth(cluster.text) == 2 {
+			cells = append(cells, styledCluster{text: continuationCell, style: cluster.style})
+		}
+	}
+	return cells
+}
+
+// Tick see ITicker
+func (m *Marquee) Tick(now time.Time) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.stopped {
+		return false
+	}
+	if offset := m.offsetAt(now.Sub(m.start)); offset != m.offset {
+		m.offset = offset
+		m.draw()
+	}
+	return true
+}
+
+// SetText see IMarquee
+func (m *Marquee) SetText(text string, color int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.cells = m.layout(text, color)
+	m.start = time.Now()
+	m.offset = 0
+	m.draw()
+}
+
+// Stop see IMarquee
+func (m *Marquee) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.stopped = true
+}
+
+// SetBounds see ILayoutNode, the marquee only uses the first row of bounds
+func (m *Marquee) SetBounds(bounds Rect) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	bounds.Height = 1
+	m.bounds = bounds
+	m.offset = m.offsetAt(time.Since(m.start))
+	m.draw()
+}
+
+// Redraw see IMarquee
+func (m *Marquee) Redraw() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.draw()
+}
+
+// sequence returns the cells scrolled through. A looping text is followed
+// by the gap before it repeats.
+func (m *Marquee) sequence() []styledCluster {
+	if m.style.Mode != MarqueeLoop || len(m.cells) <= m.bounds.Width {
+		return m.cells
+	}
+	sequence := append([]styledCluster(nil), m.cells...)
+	for _, cluster := range graphemes(m.style.Gap) {
+		sequence = append(sequence, styledCluster{text: cluster, style: DefaultStyle})
+	}
+	return sequence
+}
+
+// offsetAt returns the index of the first visible cell after elapsed time
+func (m *Marquee) offsetAt(elapsed time.Duration) int {
+	overflow := len(m.cells) - m.bounds.Width
+	if overflow <= 0 {
+		return 0
+	}
+	cellTime := time.Duration(float64(time.Second) / m.style.Speed)
+	if m.style.Mode == MarqueeLoop {
+		length := len(m.sequence())
+		cycle := m.style.Pause + time.Duration(length)*cellTime
+		shift := int(max(elapsed%cycle-m.style.Pause, 0) / cellTime)
+		if m.style.ScrollRight {
+			return (length - shift) % length
+		}
+		return shift
+	}
+	travel := time.Duration(overflow) * cellTime
+	cycle := 2 * (m.style.Pause + travel)
+	t := elapsed % cycle
+	var shift int
+	switch {
+	case t < m.style.Pause:
+		shift = 0
+	case t < m.style.Pause+travel:
+		shift = int((t - m.style.Pause) / cellTime)
+	case t < 2*m.style.Pause+travel:
+		shift = overflow
+	default:
+		shift = overflow - int((t-2*m.style.Pause-travel)/cellTime)
+	}
+	if m.style.ScrollRight {
+		return overflow - shift
+	}
+	return shift
+}
+
+// draw renders the visible window of the text and blanks the rest of the
+// row. The caller must hold the mutex.
+func (m *Marquee) draw() {
+	sequence := m.sequence()
+	var sb strings.Builder
+	for i := 0; i < m.bounds.Width && i < len(sequence); i++ {
+		cell := sequence[(m.offset+i)%len(sequence)]
+		switch {
+		case cell.text != continuationCell:
+			sb.WriteString(cell.style.Render(cell.text))
+		case i == 0:
+			// the left half of a wide cluster scrolled out of view
+			sb.WriteString(cell.style.Render(" "))
+		}
+	}
+	x := m.bounds.X + m.ui.DrawClipped(m.bounds, m.bounds.X, m.bounds.Y, sb.String(), ALREADYCOLORED)
+	if rest := m.bounds.X + m.bounds.Width - x; rest > 0 {
+		m.ui.DrawClipped(m.bounds, x, m.bounds.Y, strings.Repeat(" ", rest), BLANK)
+	}
+}
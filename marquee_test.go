@@ -0,0 +1,65 @@
+package animaterm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarqueeFits(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	m := CreateMarquee(ui, CreatePos(0, 0), 10, "short", ALREADYCOLORED, MarqueeStyle{}).(*Marquee)
+	m.Tick(time.Now().Add(time.Hour))
+	if got := row(ui, 0, 0, 8); got != "short   " {
+		t.Errorf("fitting text = %q", got)
+	}
+}
+
+func TestMarqueeScrolling(t *testing.T) {
+	// 10% of 80 columns shows 8 cells of the 12 cell text
+	text := "abcdefghijkl"
+	tests := []struct {
+		name    string
+		style   MarqueeStyle
+		elapsed time.Duration
+		want    string
+	}{
+		{name: "loop", style: MarqueeStyle{Speed: 1}, elapsed: 3 * time.Second, want: "defghijk"},
+		{name: "loop wraps around the gap", style: MarqueeStyle{Speed: 1, Gap: " | "}, elapsed: 10 * time.Second, want: "kl | abc"},
+		{name: "loop to the right", style: MarqueeStyle{Speed: 1, ScrollRight: true, Gap: "-"}, elapsed: 2 * time.Second, want: "l-abcdef"},
+		{name: "pause at the start", style: MarqueeStyle{Speed: 1, Pause: 5 * time.Second}, elapsed: 4 * time.Second, want: "abcdefgh"},
+		{name: "bounce stops at the end", style: MarqueeStyle{Speed: 1, Mode: MarqueeBounce, Pause: time.Second}, elapsed: 6 * time.Second, want: "efghijkl"},
+		{name: "bounce returns", style: MarqueeStyle{Speed: 1, Mode: MarqueeBounce, Pause: time.Second}, elapsed: 8 * time.Second, want: "cdefghij"},
+		{name: "bounce to the right", style: MarqueeStyle{Speed: 1, ScrollRight: true, Mode: MarqueeBounce}, elapsed: time.Second, want: "defghijk"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := CreateUI().(*UserInterface)
+			_ = ui.initPixels(24, 80)
+			m := CreateMarquee(ui, CreatePos(0, 0), 10, text, ALREADYCOLORED, tt.style).(*Marquee)
+			m.Tick(m.start.Add(tt.elapsed))
+			if got := row(ui, 0, 0, 8); got != tt.want {
+				t.Errorf("after %v = %q, want %q", tt.elapsed, got, tt.want)
+			}
+			if ui.pixels[0][8] != " " {
+				t.Errorf("marquee drew outside its bounds: %q", ui.pixels[0][8])
+			}
+		})
+	}
+}
+
+func TestMarqueeWideCharacters(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	m := CreateMarquee(ui, CreatePos(0, 0), 10, "世界abcdefgh", ALREADYCOLORED, MarqueeStyle{Speed: 1}).(*Marquee)
+	m.Tick(m.start.Add(time.Second))
+	// the left half of 世 scrolled out of view
+	if got := row(ui, 0, 0, 8); got != " 界abcde" {
+		t.Errorf("after one cell = %q", got)
+	}
+
+	m.Stop()
+	if m.Tick(time.Now()) {
+		t.Error("Tick() returned true after Stop()")
+	}
+}
@@ -0,0 +1,120 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+)
+
+// MultiProgress implements IMultiProgress with one bar per row
+type MultiProgress struct {
+	ui           IUserInterface
+	pos          IRelativePosition
+	percentWidth int
+	style        ProgressBarStyle
+	collapse     bool
+	bars         []*ProgressBar
+	bounds       *Rect
+	mutex        sync.Mutex
+}
+
+// CreateMultiProgress creates an empty container whose first bar is placed at
+// pos. All bars span percentWidth of the frame and share style. With collapse
+// completed bars are removed from the stack and stop drawing. The container
+// lays out its bars again whenever the terminal is resized, unless it was
+// placed by a layout, which then takes care of it.
+func CreateMultiProgress(ui IUserInterface, pos IRelativePosition, percentWidth int, style ProgressBarStyle, collapse bool) IMultiProgress {
+	mp := &MultiProgress{
+		ui:           ui,
+		pos:          pos,
+		percentWidth: percentWidth,
+		style:        style,
+		collapse:     collapse,
+	}
+	ui.OnResize(func(int, int) {
+		// a container placed by a layout is moved by the layout's handler
+		mp.mutex.Lock()
+		placed := mp.bounds != nil
+		mp.mutex.Unlock()
+		if !placed {
+			mp.Redraw()
+		}
+	})
+	return mp
+}
+
+// AddBar see IMultiProgress
+func (mp *MultiProgress) AddBar(label string) IProgressBar {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	bar := newProgressBar(mp.ui, mp.rowBounds(len(mp.bars)), label, mp.style)
+	mp.bars = append(mp.bars, bar)
+	return bar
+}
+
+// Complete see IMultiProgress
+func (mp *MultiProgress) Complete(bar IProgressBar) {
+	bar.SetProgress(1)
+	if !mp.collapse {
+		return
+	}
+
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	for i, b := range mp.bars {
+		if b != bar {
+			continue
+		}
+		mp.bars = append(mp.bars[:i], mp.bars[i+1:]...)
+		// the removed bar no longer owns a row, later updates draw nothing
+		b.SetBounds(Rect{})
+		for j := i; j < len(mp.bars); j++ {
+			mp.bars[j].SetBounds(mp.rowBounds(j))
+		}
+		last := mp.rowBounds(len(mp.bars))
+		mp.ui.DrawClipped(last, last.X, last.Y, strings.Repeat(" ", last.Width), BLANK)
+		return
+	}
+}
+
+// Bars see IMultiProgress
+func (mp *MultiProgress) Bars() []IProgressBar {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	bars := make([]IProgressBar, len(mp.bars))
+	for i, bar := range mp.bars {
+		bars[i] = bar
+	}
+	return bars
+}
+
+// Redraw see IMultiProgress
+func (mp *MultiProgress) Redraw() {
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	for i, bar := range mp.bars {
+		bar.SetBounds(mp.rowBounds(i))
+	}
+}
+
+// SetBounds see ILayoutNode. From now on the bars are stacked from the top
+// of bounds instead of the position the container was created with.
+func (mp *MultiProgress) SetBounds(bounds Rect) {
+	mp.mutex.Lock()
+	mp.bounds = &bounds
+	mp.mutex.Unlock()
+	mp.Redraw()
+}
+
+// rowBounds returns the region of the i-th bar for the current terminal size
+// or, once placed by a layout, inside its bounds
+func (mp *MultiProgress) rowBounds(i int) Rect {
+	var bounds Rect
+	if mp.bounds != nil {
+		bounds = *mp.bounds
+	} else {
+		bounds = percentRect(mp.ui, mp.pos, mp.percentWidth, 0)
+	}
+	bounds.Y += i
+	bounds.Height = 1
+	return bounds
+}
@@ -0,0 +1,85 @@
+package animaterm
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMultiProgressConcurrent(t *testing.T) {
+	ui := CreateUI()
+	mp := CreateMultiProgress(ui, CreatePos(0, 0), 100, ProgressBarStyle{}, false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bar := mp.AddBar("task")
+			for p := 0; p <= 10; p++ {
+				bar.SetProgress(float64(p) / 10)
+			}
+			mp.Complete(bar)
+		}()
+	}
+	wg.Wait()
+
+	bars := mp.Bars()
+	if len(bars) != 8 {
+		t.Fatalf("Bars() returned %d bars, want 8", len(bars))
+	}
+	rows := map[int]bool{}
+	for _, bar := range bars {
+		pb := bar.(*ProgressBar)
+		if rows[pb.bounds.Y] {
+			t.Errorf("two bars share row %d", pb.bounds.Y)
+		}
+		rows[pb.bounds.Y] = true
+		if bar.Progress() != 1 {
+			t.Errorf("completed bar has progress %v", bar.Progress())
+		}
+	}
+}
+
+func TestMultiProgressCollapse(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	mp := CreateMultiProgress(ui, CreatePos(0, 0), 100, ProgressBarStyle{}, true)
+
+	first := mp.AddBar("first")
+	second := mp.AddBar("second")
+	mp.Complete(first)
+
+	bars := mp.Bars()
+	if len(bars) != 1 || bars[0] != second {
+		t.Fatalf("Bars() after collapse = %v, want only the second bar", bars)
+	}
+	if y := second.(*ProgressBar).bounds.Y; y != 0 {
+		t.Errorf("second bar on row %d after collapse, want 0", y)
+	}
+	if ui.pixels[1][0] != " " {
+		t.Errorf("freed row was not erased: %q", ui.pixels[1][0])
+	}
+
+	// the completed bar must not draw over the bar that took its row
+	row0 := row(ui, 0, 0, 10)
+	first.SetProgress(0.5)
+	if got := row(ui, 0, 0, 10); got != row0 {
+		t.Errorf("removed bar drew over its old row: %q", got)
+	}
+}
+
+func TestMultiProgressResizeInLayout(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	mp := CreateMultiProgress(ui, CreatePos(0, 0), 100, ProgressBarStyle{}, false)
+	bar := mp.AddBar("bar")
+	mp.SetBounds(Rect{X: 2, Y: 3, Width: 20, Height: 2})
+
+	// the resize handler leaves a container placed by a layout alone
+	_ = ui.initPixels(5, 5)
+	ui.checkResize()
+	if b := bar.(*ProgressBar).bounds; b.X != 2 || b.Y != 3 {
+		t.Errorf("bar moved to %d,%d by the resize handler", b.X, b.Y)
+	}
+	if got := ui.pixels[3][2]; got != " " {
+		t.Errorf("resize handler redrew at stale bounds: %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+)
+
+// Viewport implements IViewport on top of a region of a UserInterface
+type Viewport struct {
+	ui     IUserInterface
+	bounds Rect
+	mutex  sync.RWMutex
+}
+
+// CreateViewport creates a viewport drawing into bounds of ui
+func CreateViewport(ui IUserInterface, bounds Rect) IViewport {
+	return &Viewport{ui: ui, bounds: bounds}
+}
+
+// Bounds see IViewport
+func (v *Viewport) Bounds() Rect {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.bounds
+}
+
+// SetBounds see ILayoutNode. A viewport holds no content of its own, so the
+// new region stays empty until it is drawn into.
+func (v *Viewport) SetBounds(bounds Rect) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.bounds = bounds
+}
+
+// DrawElement see IViewport
+func (v *Viewport) DrawElement(pos IRelativePosition, text string, color int) int {
+	bounds := v.Bounds()
+	text, color = resolveMarkup(text, color)
+	shiftX, shiftY := pos.GetAnchor().shift(measureText(text))
+	coordX, coordY := pos.Coords()
+	x := bounds.X + coordX.resolve(bounds.Width) + shiftX
+	y := bounds.Y + coordY.resolve(bounds.Height) + pos.GetOffset() + shiftY
+	lines := getLines(text, false)
+	if color == ALREADYCOLORED {
+		lines = carryStyles(lines)
+	}
+	for k, line := range lines {
+		v.ui.DrawClipped(bounds, x, y+k, line, color)
+	}
+	return y + len(lines) - 1 - bounds.Y
+}
+
+// DrawAt see IViewport
+func (v *Viewport) DrawAt(x int, y int, text string, color int) int {
+	bounds := v.Bounds()
+	return v.ui.DrawClipped(bounds, bounds.X+x, bounds.Y+y, text, color)
+}
+
+// Clear see IViewport
+func (v *Viewport) Clear() {
+	bounds := v.Bounds()
+	blank := strings.Repeat(" ", bounds.Width)
+	for y := 0; y < bounds.Height; y++ {
+		v.DrawAt(0, y, blank, BLANK)
+	}
+}
+
+// Panel implements IPanel as a box whose inside is exposed as a viewport
+type Panel struct {
+	ui      IUserInterface
+	bounds  Rect
+	border  BorderStyle
+	title   string
+	color   int
+	content *Viewport
+	mutex   sync.Mutex
+}
+
+// CreatePanel creates a panel at pos spanning percentWidth and percentHeight
+// of the frame and draws its frame.
+func CreatePanel(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int, border BorderStyle, title string, color int) IPanel {
+	panel := &Panel{
+		ui:     ui,
+		bounds: percentRect(ui, pos, percentWidth, percentHeight),
+		border: border,
+		title:  title,
+		color:  color,
+	}
+	panel.content = &Viewport{ui: ui, bounds: panel.inside()}
+	panel.Redraw()
+	return panel
+}
+
+// SetTitle see IPanel
+func (panel *Panel) SetTitle(title string) {
+	panel.mutex.Lock()
+	panel.title = title
+	panel.mutex.Unlock()
+	panel.Redraw()
+}
+
+// Content see IPanel
+func (panel *Panel) Content() IViewport {
+	return panel.content
+}
+
+// SetBounds see ILayoutNode. The content viewport moves along with the
+// frame, what was drawn into it has to be drawn again.
+func (panel *Panel) SetBounds(bounds Rect) {
+	panel.mutex.Lock()
+	panel.bounds = bounds
+	panel.content.SetBounds(panel.inside())
+	panel.mutex.Unlock()
+	panel.Redraw()
+}
+
+// inside returns the region within the frame. The caller must hold the mutex.
+func (panel *Panel) inside() Rect {
+	return Rect{
+		X:      panel.bounds.X + 1,
+		Y:      panel.bounds.Y + 1,
+		Width:  max(panel.bounds.Width-2, 0),
+		Height: max(panel.bounds.Height-2, 0),
+	}
+}
+
+// Redraw see IPanel
+func (panel *Panel) Redraw() {
+	panel.mutex.Lock()
+	defer panel.mutex.Unlock()
+	_ = panel.ui.DrawBox(panel.bounds, panel.border, panel.title, panel.color)
+}
@@ -0,0 +1,339 @@
+package animaterm
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Emitter describes how particles are spawned and how they behave.
+// Particles spawn at the emitter's position, spread over Width cells to the
+// right of it, with a velocity of VX and VY cells per second plus a random
+// part of up to SpreadX and SpreadY in either direction. Gravity accelerates
+// them downwards in cells per second². Over their Lifetime they pass through
+// the Glyphs and Colors ramps; with Random every particle keeps one glyph and
+// color picked at random instead.
+// Burst particles are spawned at once, then Rate particles per second for
+// Duration, or until the particle system is stopped if Duration is 0.
+type Emitter struct {
+	Width    int
+	Rate     float64
+	Burst    int
+	Duration time.Duration
+	VX       float64
+	VY       float64
+	SpreadX  float64
+	SpreadY  float64
+	Gravity  float64
+	Lifetime time.Duration
+	Glyphs   []string
+	Colors   []int
+	Random   bool
+}
+
+// Built-in emitters
+var (
+	// EmitterConfetti bursts colorful pieces upwards, e.g. on success
+	EmitterConfetti = Emitter{
+		Burst:    60,
+		VY:       -12,
+		SpreadX:  16,
+		SpreadY:  6,
+		Gravity:  18,
+		Lifetime: 2 * time.Second,
+		Glyphs:   []string{"■", "▪", "●", "◆", "*", "•"},
+		Colors:   []int{196, 202, 226, 46, 51, 21, 201},
+		Random:   true,
+	}
+	// EmitterSparks continuously throws sparks that cool down from white to red
+	EmitterSparks = Emitter{
+		Rate:     40,
+		VY:       -8,
+		SpreadX:  10,
+		SpreadY:  4,
+		Gravity:  16,
+		Lifetime: 800 * time.Millisecond,
+		Glyphs:   []string{"*", "+", "·", "."},
+		Colors:   []int{231, 226, 214, 202, 160},
+	}
+	// EmitterSnow lets flakes fall slowly, set Width to the width of the area
+	EmitterSnow = Emitter{
+		Rate:     6,
+		VY:       3,
+		SpreadX:  1,
+		SpreadY:  1,
+		Lifetime: 20 * time.Second,
+		Glyphs:   []string{"*", "·", "."},
+		Colors:   []int{255, 252, 250},
+		Random:   true,
+	}
+)
+
+// emitterState is an emitter placed at an absolute cell
+type emitterState struct {
+	Emitter
+	x       int
+	y       int
+	started time.Time
+	carry   float64
+}
+
+// particle is a single particle in cells and cells per second
+type particle struct {
+	x       float64
+	y       float64
+	vx      float64
+	vy      float64
+	born    time.Time
+	emitter *emitterState
+	glyph   string
+	color   int
+}
+
+// savedCell keeps the pixels of area, which a particle at cell covers, and
+// the pixel the particle drew at cell last
+type savedCell struct {
+	cell   [2]int
+	area   Rect
+	pixels [][]string
+	drawn  string
+}
+
+// ParticleSystem implements IParticleSystem and ITicker. Particles are drawn
+// like a layer on top of the screen: the pixels they cover are kept and put
+// back once the particles move on.
+type ParticleSystem struct {
+	ui         IUserInterface
+	bounds     Rect
+	emitters   []*emitterState
+	particles  []*particle
+	under      []*savedCell
+	random     *rand.Rand
+	last       time.Time
+	registered bool
+	mutex      sync.Mutex
+}
+
+// CreateParticleSystem creates a particle system whose particles are shown
+// in the region at pos spanning percentWidth and percentHeight of the frame.
+// seed makes the particles reproducible, 0 picks a random seed.
+func CreateParticleSystem(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int, seed int64) IParticleSystem {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &ParticleSystem{
+		ui:     ui,
+		bounds: percentRect(ui, pos, percentWidth, percentHeight),
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Emit see IParticleSystem
+func (ps *ParticleSystem) Emit(pos IRelativePosition, emitter Emitter) {
+	x, y := ps.ui.PositionToAbsolute(pos)
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	now := time.Now()
+	state := &emitterState{Emitter: emitter, x: x, y: y, started: now}
+	for i := 0; i < emitter.Burst; i++ {
+		ps.spawn(state, now)
+	}
+	if emitter.Rate > 0 {
+		ps.emitters = append(ps.emitters, state)
+	}
+	if !ps.registered {
+		ps.registered = true
+		ps.last = now
+		ps.ui.AddTicker(ps)
+	}
+}
+
+// Count see IParticleSystem
+func (ps *ParticleSystem) Count() int {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	return len(ps.particles)
+}
+
+// Stop see IParticleSystem
+func (ps *ParticleSystem) Stop() {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.emitters = nil
+}
+
+// Clear see IParticleSystem
+func (ps *ParticleSystem) Clear() {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.emitters = nil
+	ps.particles = nil
+	ps.draw()
+}
+
+// SetBounds see ILayoutNode
+func (ps *ParticleSystem) SetBounds(bounds Rect) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.bounds = bounds
+	ps.draw()
+}
+
+// Tick see ITicker
+func (ps *ParticleSystem) Tick(now time.Time) bool {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	dt := now.Sub(ps.last).Seconds()
+	if dt < 0 {
+		return ps.registered
+	}
+	ps.last = now
+
+	emitters := ps.emitters[:0]
+	for _, emitter := range ps.emitters {
+		if emitter.Duration > 0 && now.Sub(emitter.started) > emitter.Duration {
+			continue
+		}
+		emitter.carry += emitter.Rate * dt
+		for ; emitter.carry >= 1; emitter.carry-- {
+			ps.spawn(emitter, now)
+		}
+		emitters = append(emitters, emitter)
+	}
+	ps.emitters = emitters
+
+	particles := ps.particles[:0]
+	for _, p := range ps.particles {
+		p.vy += p.emitter.Gravity * dt
+		p.x += p.vx * dt
+		p.y += p.vy * dt
+		// particles may fly above the region and fall back, but never return
+		// from below or the sides
+		x, y := int(math.Round(p.x)), int(math.Round(p.y))
+		if now.Sub(p.born) >= p.emitter.Lifetime || y >= ps.bounds.Y+ps.bounds.Height ||
+			x < ps.bounds.X || x >= ps.bounds.X+ps.bounds.Width {
+			continue
+		}
+		particles = append(particles, p)
+	}
+	ps.particles = particles
+	ps.draw()
+
+	ps.registered = len(ps.particles) > 0 || len(ps.emitters) > 0
+	return ps.registered
+}
+
+// spawn adds a particle of emitter. The caller must hold the mutex.
+func (ps *ParticleSystem) spawn(emitter *emitterState, now time.Time) {
+	spread := func(amount float64) float64 {
+		return (ps.random.Float64()*2 - 1) * amount
+	}
+	p := &particle{
+		x:       float64(emitter.x + ps.random.Intn(max(emitter.Width, 1))),
+		y:       float64(emitter.y),
+		vx:      emitter.VX + spread(emitter.SpreadX),
+		vy:      emitter.VY + spread(emitter.SpreadY),
+		born:    now,
+		emitter: emitter,
+	}
+	if emitter.Random {
+		if len(emitter.Glyphs) > 0 {
+			p.glyph = emitter.Glyphs[ps.random.Intn(len(emitter.Glyphs))]
+		}
+		if len(emitter.Colors) > 0 {
+			p.color = emitter.Colors[ps.random.Intn(len(emitter.Colors))]
+		}
+	}
+	ps.particles = append(ps.particles, p)
+}
+
+// look returns the glyph and color of a particle at its current age
+func (ps *ParticleSystem) look(p *particle) (string, int) {
+	if p.emitter.Random {
+		return p.glyph, p.color
+	}
+	age := float64(ps.last.Sub(p.born)) / float64(max(p.emitter.Lifetime, 1))
+	glyph, color := "*", WHITE
+	if n := len(p.emitter.Glyphs); n > 0 {
+		glyph = p.emitter.Glyphs[min(int(age*float64(n)), n-1)]
+	}
+	if n := len(p.emitter.Colors); n > 0 {
+		color = p.emitter.Colors[min(int(age*float64(n)), n-1)]
+	}
+	return glyph, color
+}
+
+// draw renders the particles inside the bounds and puts back the pixels of
+// cells no particle covers anymore. Saved pixels are put back newest first,
+// and only where the particle is still on top; cells drawn over since are
+// saved again. The caller must hold the mutex.
+func (ps *ParticleSystem) draw() {
+	type appearance struct {
+		glyph string
+		color int
+	}
+	cells := map[[2]int]appearance{}
+	for _, p := range ps.particles {
+		x, y := int(math.Round(p.x)), int(math.Round(p.y))
+		if ps.bounds.Contains(x, y) {
+			glyph, color := ps.look(p)
+			cells[[2]int{x, y}] = appearance{glyph, color}
+		}
+	}
+	saved := map[[2]int]*savedCell{}
+	under := []*savedCell{}
+	for i := len(ps.under) - 1; i >= 0; i-- {
+		s := ps.under[i]
+		if _, ok := cells[s.cell]; ok {
+			saved[s.cell] = s
+			under = append(under, s)
+		} else if ps.pixel(s.cell) == s.drawn {
+			ps.ui.Restore(s.area.X, s.area.Y, s.pixels)
+		}
+	}
+	slices.Reverse(under)
+	ps.under = under
+
+	order := make([][2]int, 0, len(cells))
+	for cell := range cells {
+		order = append(order, cell)
+	}
+	slices.SortFunc(order, func(a [2]int, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[1], b[1]), cmp.Compare(a[0], b[0]))
+	})
+	for _, cell := range order {
+		if s, ok := saved[cell]; !ok || ps.pixel(cell) != s.drawn {
+			if ok {
+				ps.under = slices.DeleteFunc(ps.under, func(u *savedCell) bool { return u == s })
+			}
+			saved[cell] = ps.save(cell)
+			ps.under = append(ps.under, saved[cell])
+		}
+		appearance := cells[cell]
+		ps.ui.DrawClipped(ps.bounds, cell[0], cell[1], appearance.glyph, appearance.color)
+		saved[cell].drawn = ps.pixel(cell)
+	}
+}
+
+// save snapshots the pixels under a particle at cell, both halves if the
+// cell is part of a wide cluster
+func (ps *ParticleSystem) save(cell [2]int) *savedCell {
+	area := Rect{X: cell[0], Y: cell[1], Width: 1, Height: 1}
+	around := ps.ui.Snapshot(Rect{X: cell[0] - 1, Y: cell[1], Width: 3, Height: 1})[0]
+	switch {
+	case around[1] == continuationCell:
+		area.X--
+		area.Width = 2
+	case around[2] == continuationCell:
+		area.Width = 2
+	}
+	return &savedCell{cell: cell, area: area, pixels: ps.ui.Snapshot(area)}
+}
+
+// pixel returns the current pixel at cell
+func (ps *ParticleSystem) pixel(cell [2]int) string {
+	return ps.ui.Snapshot(Rect{X: cell[0], Y: cell[1], Width: 1, Height: 1})[0][0]
+}
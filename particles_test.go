@@ -0,0 +1,231 @@
+package animaterm
+
+import (
+	"testing"
+	"time"
+)
+
+// dot is a single particle falling straight down from where it is emitted
+var dot = Emitter{Burst: 1, Gravity: 2, Lifetime: 3 * time.Second, Glyphs: []string{"*"}, Colors: []int{WHITE}}
+
+func TestParticleGravity(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 100, 1).(*ParticleSystem)
+	ps.Emit(CreatePos(50, 50), dot)
+	start := ps.last
+
+	tests := []struct {
+		elapsed time.Duration
+		y       int
+	}{
+		{elapsed: 0, y: 12},
+		// v = 2 after one second, moving 2 cells
+		{elapsed: time.Second, y: 14},
+		// v = 4 after another second, moving 4 cells
+		{elapsed: 2 * time.Second, y: 18},
+	}
+	for _, tt := range tests {
+		if !ps.Tick(start.Add(tt.elapsed)) {
+			t.Fatalf("Tick(%v) = false with a living particle", tt.elapsed)
+		}
+		for y := 0; y < ui.height; y++ {
+			got := stripANSI(ui.pixels[y][40])
+			if want := map[bool]string{true: "*", false: " "}[y == tt.y]; got != want {
+				t.Errorf("after %v row %d = %q, want %q", tt.elapsed, y, got, want)
+			}
+		}
+	}
+}
+
+func TestParticleLifetime(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 100, 1).(*ParticleSystem)
+	emitter := dot
+	emitter.Burst = 5
+	emitter.Gravity = 0
+	ps.Emit(CreatePos(50, 50), emitter)
+	if got := ps.Count(); got != 5 {
+		t.Fatalf("Count() after a burst of 5 = %d", got)
+	}
+	start := ps.last
+	ps.Tick(start)
+	if ps.Tick(start.Add(emitter.Lifetime)) {
+		t.Error("Tick() = true after all particles died")
+	}
+	if got := ps.Count(); got != 0 {
+		t.Errorf("Count() after the lifetime = %d", got)
+	}
+	if got := ui.pixels[12][40]; got != " " {
+		t.Errorf("dead particle left %q behind", got)
+	}
+}
+
+func TestParticleRate(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 100, 1).(*ParticleSystem)
+	emitter := dot
+	emitter.Burst = 0
+	emitter.Rate = 10
+	emitter.Duration = time.Second
+	ps.Emit(CreatePos(50, 0), emitter)
+	start := ps.last
+	ps.Tick(start.Add(500 * time.Millisecond))
+	if got := ps.Count(); got != 5 {
+		t.Errorf("Count() after half a second at 10 per second = %d", got)
+	}
+	ps.Tick(start.Add(2 * time.Second))
+	spawned := ps.Count()
+	ps.Tick(start.Add(2500 * time.Millisecond))
+	if got := ps.Count(); got > spawned {
+		t.Errorf("emitter kept spawning after its duration: %d > %d", got, spawned)
+	}
+
+	ps.Emit(CreatePos(50, 0), EmitterSparks)
+	ps.Stop()
+	before := ps.Count()
+	ps.Tick(ps.last.Add(100 * time.Millisecond))
+	if got := ps.Count(); got > before {
+		t.Errorf("stopped emitter kept spawning: %d > %d", got, before)
+	}
+}
+
+func TestParticleRestoresScreen(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ui.DrawElement(CreatePos(0, 0), "under", ALREADYCOLORED)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 50, 1).(*ParticleSystem)
+	emitter := dot
+	emitter.Gravity = 0
+	emitter.VY = 1
+	emitter.Lifetime = time.Minute
+	ps.Emit(CreatePos(0, 0), emitter)
+	start := ps.last
+
+	ps.Tick(start)
+	if got := stripANSI(row(ui, 0, 0, 5)); got != "*nder" {
+		t.Errorf("particle over text = %q", got)
+	}
+	ps.Tick(start.Add(time.Second))
+	if got := row(ui, 0, 0, 5); got != "under" {
+		t.Errorf("after the particle moved on = %q", got)
+	}
+
+	ps.Emit(CreatePos(0, 0), emitter)
+	ps.Tick(ps.last)
+	ps.Clear()
+	if got := row(ui, 0, 0, 5); got != "under" {
+		t.Errorf("after Clear() = %q", got)
+	}
+	if got := stripANSI(ui.pixels[1][0]); got != " " {
+		t.Errorf("Clear() left %q behind", got)
+	}
+
+	// particles leaving the bounds are dropped, not drawn below them
+	ps.Emit(CreatePos(0, 0), emitter)
+	ps.Tick(ps.last.Add(time.Duration(ps.bounds.Height) * time.Second))
+	if got := ps.Count(); got != 0 {
+		t.Errorf("Count() after falling out of the bounds = %d", got)
+	}
+	if got := stripANSI(ui.pixels[min(ps.bounds.Height, ui.height-1)][0]); got != " " {
+		t.Errorf("particle drawn below the bounds: %q", got)
+	}
+}
+
+func TestParticleKeepsNewPixels(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ui.DrawElement(CreatePos(0, 0), "under", ALREADYCOLORED)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 50, 1).(*ParticleSystem)
+	emitter := dot
+	emitter.Gravity = 0
+	emitter.Lifetime = time.Minute
+	ps.Emit(CreatePos(0, 0), emitter)
+	ps.Tick(ps.last)
+
+	// the screen is redrawn underneath the particle
+	ui.DrawElement(CreatePos(0, 0), "fresh", ALREADYCOLORED)
+	ps.Tick(ps.last.Add(time.Second))
+	if got := stripANSI(row(ui, 0, 0, 5)); got != "*resh" {
+		t.Errorf("particle over new text = %q", got)
+	}
+	ps.Clear()
+	if got := row(ui, 0, 0, 5); got != "fresh" {
+		t.Errorf("after Clear() = %q, want the new text", got)
+	}
+
+	// the screen is redrawn after the particle and it dies
+	ps.Emit(CreatePos(0, 0), emitter)
+	ps.Tick(ps.last)
+	ui.DrawElement(CreatePos(0, 0), "later", ALREADYCOLORED)
+	ps.Clear()
+	if got := row(ui, 0, 0, 5); got != "later" {
+		t.Errorf("after Clear() = %q, want the text drawn last", got)
+	}
+}
+
+func TestParticleOverWideCluster(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ui.DrawElement(CreatePos(0, 0), "世界", ALREADYCOLORED)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 50, 1).(*ParticleSystem)
+	emitter := dot
+	emitter.Gravity = 0
+	emitter.Lifetime = time.Minute
+	for _, x := range []int{1, 2} {
+		// 1 is the right half of 世, 2 the left half of 界
+		ps.Emit(CreateMixedPos(Coord{Cells: x}, Coord{}), emitter)
+	}
+	ps.Tick(ps.last)
+	if got := stripANSI(row(ui, 0, 0, 4)); got != " ** " {
+		t.Errorf("particles over wide clusters = %q", got)
+	}
+	ps.Clear()
+	if got := row(ui, 0, 0, 4); got != "世界" {
+		t.Errorf("after Clear() = %q", got)
+	}
+	if ui.pixels[0][1] != continuationCell || ui.pixels[0][3] != continuationCell {
+		t.Errorf("continuation cells not restored: %q", ui.pixels[0][:4])
+	}
+}
+
+func TestParticleEmitWhileFinishing(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ps := CreateParticleSystem(ui, CreatePos(0, 0), 100, 100, 1).(*ParticleSystem)
+	ps.Emit(CreatePos(50, 50), dot)
+	// runs after the particle system finished but before it is removed
+	ui.AddTicker(tickerFunc(func(time.Time) bool {
+		ps.Emit(CreatePos(50, 50), dot)
+		return false
+	}))
+
+	ui.runTickers(ps.last.Add(dot.Lifetime))
+	if len(ui.tickers) != 1 || ui.tickers[0] != ITicker(ps) {
+		t.Fatalf("tickers after emitting while finishing = %v, want the particle system", ui.tickers)
+	}
+	if !ps.Tick(ps.last) || ps.Count() != 1 {
+		t.Errorf("particle system not running after emitting again, %d particles", ps.Count())
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ui.DrawElement(CreatePos(0, 0), "ab\ncd", ALREADYCOLORED)
+	snapshot := ui.Snapshot(Rect{X: -1, Y: 0, Width: 3, Height: 2})
+	want := [][]string{{" ", "a", "b"}, {" ", "c", "d"}}
+	for y := range want {
+		for x := range want[y] {
+			if snapshot[y][x] != want[y][x] {
+				t.Errorf("snapshot[%d][%d] = %q, want %q", y, x, snapshot[y][x], want[y][x])
+			}
+		}
+	}
+	ui.Restore(10, 5, snapshot[:1])
+	if got := row(ui, 5, 10, 3); got != " ab" {
+		t.Errorf("restored = %q", got)
+	}
+}
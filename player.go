@@ -0,0 +1,222 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// PlayerOptions configures the playback of a recording. Speed multiplies
+// the speed of the recording (1 if unset) and IdleLimit caps the pauses
+// between two events, 0 keeps them as recorded.
+type PlayerOptions struct {
+	Speed     float64
+	IdleLimit time.Duration
+}
+
+// Player implements IPlayer and ITicker. The recording is replayed on an
+// emulated terminal of the recorded size, whose top-left part is shown in
+// the region of the player.
+type Player struct {
+	ui         IUserInterface
+	bounds     Rect
+	cast       *Asciicast
+	speed      float64
+	times      []time.Duration
+	screen     *emulator
+	next       int
+	position   time.Duration
+	last       time.Time
+	paused     bool
+	registered bool
+	mutex      sync.Mutex
+}
+
+// CreatePlayer plays cast in the region at pos spanning percentWidth and
+// percentHeight of the frame and registers it with the draw loop of ui.
+// Recordings without a size take the size of the region.
+func CreatePlayer(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int, cast *Asciicast, options PlayerOptions) IPlayer {
+	if options.Speed <= 0 {
+		options.Speed = 1
+	}
+	p := &Player{
+		ui:     ui,
+		bounds: percentRect(ui, pos, percentWidth, percentHeight),
+		cast:   cast,
+		speed:  options.Speed,
+	}
+	var previous, at time.Duration
+	for _, event := range cast.Events {
+		pause := max(event.Time-previous, 0)
+		if options.IdleLimit > 0 {
+			pause = min(pause, options.IdleLimit)
+		}
+		previous = event.Time
+		at += pause
+		p.times = append(p.times, at)
+	}
+	p.reset()
+	p.draw()
+	p.play()
+	return p
+}
+
+// Tick see ITicker
+func (p *Player) Tick(now time.Time) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.paused || p.next == len(p.times) {
+		p.registered = false
+		return false
+	}
+	if elapsed := now.Sub(p.last); elapsed > 0 {
+		p.last = now
+		p.advance(p.position + time.Duration(float64(elapsed)*p.speed))
+		p.draw()
+	}
+	return true
+}
+
+// Pause see IPlayer
+func (p *Player) Pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.paused = true
+}
+
+// Resume see IPlayer
+func (p *Player) Resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.paused = false
+	p.play()
+}
+
+// Paused see IPlayer
+func (p *Player) Paused() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.paused
+}
+
+// Seek see IPlayer
+func (p *Player) Seek(position time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.advance(min(max(position, 0), p.duration()))
+	p.draw()
+	p.play()
+}
+
+// Position see IPlayer
+func (p *Player) Position() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.position
+}
+
+// Duration see IPlayer
+func (p *Player) Duration() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.duration()
+}
+
+// SetSpeed see IPlayer
+func (p *Player) SetSpeed(speed float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if speed > 0 {
+		p.speed = speed
+	}
+}
+
+// Done see IPlayer
+func (p *Player) Done() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.next == len(p.times)
+}
+
+// SetBounds see ILayoutNode
+func (p *Player) SetBounds(bounds Rect) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.bounds = bounds
+	if p.cast.Width <= 0 || p.cast.Height <= 0 {
+		// the screen takes the size of the region
+		position := p.position
+		p.reset()
+		p.advance(position)
+	}
+	p.screen.dirty = true
+	p.draw()
+}
+
+// duration returns the length of the playback. The caller must hold the mutex.
+func (p *Player) duration() time.Duration {
+	if len(p.times) == 0 {
+		return 0
+	}
+	return p.times[len(p.times)-1]
+}
+
+// play registers the player with the draw loop unless it is paused, done or
+// already registered. The caller must hold the mutex.
+func (p *Player) play() {
+	p.last = time.Now()
+	if p.paused || p.registered || p.next == len(p.times) {
+		return
+	}
+	p.registered = true
+	p.ui.AddTicker(p)
+}
+
+// reset starts the playback over on an empty screen. The caller must hold
+// the mutex.
+func (p *Player) reset() {
+	width, height := p.cast.Width, p.cast.Height
+	if width <= 0 || height <= 0 {
+		width, height = p.bounds.Width, p.bounds.Height
+	}
+	p.screen = newEmulator(width, height)
+	p.next = 0
+	p.position = 0
+}
+
+// advance replays the events up to position, starting over to seek
+// backwards. The caller must hold the mutex.
+func (p *Player) advance(position time.Duration) {
+	if position < p.position {
+		p.reset()
+	}
+	for ; p.next < len(p.times) && p.times[p.next] <= position; p.next++ {
+		event := p.cast.Events[p.next]
+		if event.Kind == "r" {
+			if width, height, ok := parseSize(event.Data); ok {
+				p.screen.resize(width, height)
+			}
+			continue
+		}
+		p.screen.write(event.Data)
+	}
+	p.position = min(position, p.duration())
+}
+
+// draw renders the screen into the region if it changed. The caller must
+// hold the mutex.
+func (p *Player) draw() {
+	if !p.screen.dirty {
+		return
+	}
+	p.screen.dirty = false
+	for y := 0; y < p.bounds.Height; y++ {
+		x := p.bounds.X
+		if y < p.screen.height {
+			x += p.ui.DrawClipped(p.bounds, x, p.bounds.Y+y, p.screen.line(y, p.bounds.Width), ALREADYCOLORED)
+		}
+		if rest := p.bounds.X + p.bounds.Width - x; rest > 0 {
+			p.ui.DrawClipped(p.bounds, x, p.bounds.Y+y, strings.Repeat(" ", rest), BLANK)
+		}
+	}
+}
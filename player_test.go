@@ -0,0 +1,205 @@
+package animaterm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const testCast = `{"version": 2, "width": 6, "height": 2, "title": "demo"}
+[0.5, "o", "ab"]
+[1.0, "i", "ignored"]
+[1.0, "o", "\u001b[31mcd\u001b[0m"]
+[11.0, "o", "\r\nef"]
+[12.0, "r", "4x2"]
+`
+
+func TestParseAsciicast(t *testing.T) {
+	cast, err := ParseAsciicast(strings.NewReader(testCast))
+	if err != nil {
+		t.Fatalf("ParseAsciicast returned error: %v", err)
+	}
+	if cast.Width != 6 || cast.Height != 2 || cast.Title != "demo" {
+		t.Errorf("header = %dx%d %q", cast.Width, cast.Height, cast.Title)
+	}
+	want := []AsciicastEvent{
+		{Time: 500 * time.Millisecond, Kind: "o", Data: "ab"},
+		{Time: time.Second, Kind: "o", Data: "\033[31mcd\033[0m"},
+		{Time: 11 * time.Second, Kind: "o", Data: "\r\nef"},
+		{Time: 12 * time.Second, Kind: "r", Data: "4x2"},
+	}
+	if len(cast.Events) != len(want) {
+		t.Fatalf("parsed %d events, want %d", len(cast.Events), len(want))
+	}
+	for i := range want {
+		if cast.Events[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, cast.Events[i], want[i])
+		}
+	}
+
+	for _, input := range []string{
+		``,
+		`{"version": 1}`,
+		`{"version": 2}` + "\n" + `[1.0, "o"]`,
+		`{"version": 2}` + "\n" + `["1.0", "o", "x"]`,
+		`{"version": 2}` + "\n" + `[1.0, "o", "x"`,
+	} {
+		if _, err := ParseAsciicast(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseAsciicast(%q) should return an error", input)
+		}
+	}
+
+	fsys := fstest.MapFS{"demo.cast": {Data: []byte(testCast)}}
+	if _, err := LoadAsciicast(fsys, "demo.cast"); err != nil {
+		t.Errorf("LoadAsciicast returned error: %v", err)
+	}
+}
+
+func TestAsciicastRoundTrip(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	var terminal, recording bytes.Buffer
+	ui.SetOutput(&terminal)
+	rec, err := CreateRecorder(ui, &recording, "")
+	if err != nil {
+		t.Fatalf("CreateRecorder returned error: %v", err)
+	}
+	_ = ui.moveCursorTo(2, 3)
+	_, _ = ui.Output().Write([]byte("hi"))
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	cast, err := ParseAsciicast(&recording)
+	if err != nil {
+		t.Fatalf("ParseAsciicast returned error: %v", err)
+	}
+	screen := newEmulator(cast.Width, cast.Height)
+	for _, event := range cast.Events {
+		screen.write(event.Data)
+	}
+	if got := stripANSI(screen.line(2, 4)); got != " hi " {
+		t.Errorf("replayed row = %q", got)
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	cast, err := ParseAsciicast(strings.NewReader(testCast))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	// 10% of 80 columns and 25% of 24 rows is a region of 8x6 cells
+	p := CreatePlayer(ui, CreatePos(0, 0), 10, 25, cast, PlayerOptions{IdleLimit: 2 * time.Second}).(*Player)
+
+	if got := p.Duration(); got != 4*time.Second {
+		t.Errorf("Duration() with idle time capped = %v, want 4s", got)
+	}
+	tests := []struct {
+		position time.Duration
+		want     []string
+	}{
+		{position: 0, want: []string{"        ", "        "}},
+		{position: time.Second, want: []string{"abcd    ", "        "}},
+		{position: 3 * time.Second, want: []string{"abcd    ", "ef      "}},
+		{position: 500 * time.Millisecond, want: []string{"ab      ", "        "}},
+		// the screen shrank to 4 columns
+		{position: 4 * time.Second, want: []string{"abcd    ", "ef      "}},
+	}
+	for _, tt := range tests {
+		p.Seek(tt.position)
+		if got := p.Position(); got != tt.position {
+			t.Errorf("Position() = %v after seeking to %v", got, tt.position)
+		}
+		for y, want := range tt.want {
+			if got := stripANSI(row(ui, y, 0, 8)); got != want {
+				t.Errorf("at %v row %d = %q, want %q", tt.position, y, got, want)
+			}
+		}
+	}
+	if p.screen.width != 4 {
+		t.Errorf("screen width after the resize event = %d, want 4", p.screen.width)
+	}
+	if !p.Done() {
+		t.Error("Done() = false at the end")
+	}
+}
+
+func TestPlayerTicking(t *testing.T) {
+	cast, err := ParseAsciicast(strings.NewReader(testCast))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	p := CreatePlayer(ui, CreatePos(0, 0), 10, 25, cast, PlayerOptions{Speed: 2}).(*Player)
+	start := p.last
+
+	if !p.Tick(start.Add(300 * time.Millisecond)) {
+		t.Fatal("Tick() = false while playing")
+	}
+	if got := p.Position(); got != 600*time.Millisecond {
+		t.Errorf("Position() at double speed = %v, want 600ms", got)
+	}
+	if got := stripANSI(row(ui, 0, 0, 4)); got != "ab  " {
+		t.Errorf("row after 600ms = %q", got)
+	}
+
+	p.Pause()
+	if p.Tick(start.Add(time.Second)) {
+		t.Error("Tick() = true while paused")
+	}
+	if got := p.Position(); got != 600*time.Millisecond {
+		t.Errorf("Position() moved while paused: %v", got)
+	}
+	p.Resume()
+	if !p.registered {
+		t.Error("Resume() did not register the player again")
+	}
+
+	p.SetSpeed(100)
+	p.Tick(p.last.Add(time.Second))
+	if !p.Done() || p.Position() != p.Duration() {
+		t.Errorf("not done after playing past the end: %v of %v", p.Position(), p.Duration())
+	}
+	if p.Tick(p.last.Add(time.Second)) {
+		t.Error("Tick() = true after the playback is done")
+	}
+}
+
+func TestPlayerResumeWhileFinishing(t *testing.T) {
+	cast, err := ParseAsciicast(strings.NewReader(testCast))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name    string
+		stop    func(p *Player)
+		restart func(p *Player)
+	}{
+		{name: "resume after a pause", stop: func(p *Player) { p.Pause() }, restart: func(p *Player) { p.Resume() }},
+		{name: "seek after the end", stop: func(p *Player) { p.Seek(p.Duration()) }, restart: func(p *Player) { p.Seek(0) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := CreateUI().(*UserInterface)
+			_ = ui.initPixels(24, 80)
+			p := CreatePlayer(ui, CreatePos(0, 0), 10, 25, cast, PlayerOptions{}).(*Player)
+			tt.stop(p)
+			// runs after the player finished but before it is removed
+			ui.AddTicker(tickerFunc(func(time.Time) bool {
+				tt.restart(p)
+				return false
+			}))
+
+			ui.runTickers(time.Now())
+			if len(ui.tickers) != 1 || ui.tickers[0] != ITicker(p) {
+				t.Fatalf("tickers after restarting while finishing = %v, want the player", ui.tickers)
+			}
+			if !p.Tick(p.last.Add(time.Second)) || p.Position() == 0 {
+				t.Errorf("player not playing after restarting, position %v", p.Position())
+			}
+		})
+	}
+}
@@ -1,12 +1,109 @@
 package animaterm
 
-// Position implements IRelativePosition using percentage-based coordinates.
-// All coordinates are automatically clamped to the range [-100, 100].
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Anchor selects which point of an element's bounding box is placed at its position
+type Anchor int
+
+// AnchorTopLeft ...
+const (
+	AnchorTopLeft Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// shift returns the cells an element of the given size has to be moved by so
+// that its anchor point lands on the position
+func (a Anchor) shift(width int, height int) (int, int) {
+	return -width * (int(a) % 3) / 2, -height * (int(a) / 3) / 2
+}
+
+// Coord is a coordinate along one axis: a (fractional) percentage of the
+// frame plus a number of absolute cells. Coord{Percent: 50, Cells: -10}
+// addresses the cell ten columns left of the center.
+type Coord struct {
+	Percent float64
+	Cells   int
+}
+
+// ParseCoord parses coordinates like "50%", "12", "33.5%" or "50% - 10",
+// where numbers with a percent sign are percentages and numbers without are
+// cells. Terms are summed up.
+func ParseCoord(s string) (Coord, error) {
+	coord := Coord{}
+	rest := strings.ReplaceAll(s, " ", "")
+	if rest == "" {
+		return coord, fmt.Errorf("empty coordinate")
+	}
+	for rest != "" {
+		sign := 1.0
+		if rest[0] == '+' || rest[0] == '-' {
+			if rest[0] == '-' {
+				sign = -1
+			}
+			rest = rest[1:]
+		}
+		end := strings.IndexAny(rest, "+-")
+		if end == -1 {
+			end = len(rest)
+		}
+		term := rest[:end]
+		rest = rest[end:]
+		if percent, ok := strings.CutSuffix(term, "%"); ok {
+			value, err := strconv.ParseFloat(percent, 64)
+			if err != nil {
+				return Coord{}, fmt.Errorf("invalid percentage %q in coordinate %q", term, s)
+			}
+			coord.Percent += sign * value
+		} else {
+			value, err := strconv.Atoi(term)
+			if err != nil {
+				return Coord{}, fmt.Errorf("invalid cell count %q in coordinate %q", term, s)
+			}
+			coord.Cells += int(sign) * value
+		}
+	}
+	return coord, nil
+}
+
+// resolve converts the coordinate into cells along an axis of the given
+// extent. Fractions of a cell are floored, so negative percentages round the
+// same way as positive ones.
+func (c Coord) resolve(extent int) int {
+	return int(math.Floor(float64(extent)*c.Percent/100)) + c.Cells
+}
+
+// clampPercent limits a percentage to the range [-100, 100]
+func clampPercent(percent float64) float64 {
+	if percent > 100 {
+		return 100
+	}
+	if percent < -100 {
+		return -100
+	}
+	return percent
+}
+
+// Position implements IRelativePosition using percentage-based coordinates
+// that may carry fractions and absolute cell offsets.
+// The percentages are automatically clamped to the range [-100, 100].
 // The offset field supports multi-line text rendering by tracking vertical displacement.
 type Position struct {
-	x      int
-	y      int
+	x      Coord
+	y      Coord
 	offset int
+	anchor Anchor
 }
 
 // CreatePos creates a new Position with the specified percentage coordinates.
@@ -18,42 +115,43 @@ func CreatePos(x int, y int) IRelativePosition {
 	return pos
 }
 
+// CreateMixedPos creates a new Position from coordinates combining fractional
+// percentages and absolute cells. Percentages are clamped to [-100, 100].
+func CreateMixedPos(x Coord, y Coord) IRelativePosition {
+	x.Percent = clampPercent(x.Percent)
+	y.Percent = clampPercent(y.Percent)
+	return &Position{x: x, y: y}
+}
+
 // GetX  see IRelativePosition
 func (p *Position) GetX() int {
-	return p.x
+	return int(p.x.Percent)
 }
 
 // GetY see IRelativePosition
 func (p *Position) GetY() int {
-	return p.y
+	return int(p.y.Percent)
 }
 
 // GetXandY see IRelativePosition
 func (p *Position) GetXandY() (int, int) {
+	return p.GetX(), p.GetY()
+}
+
+// Coords see IRelativePosition
+func (p *Position) Coords() (Coord, Coord) {
 	return p.x, p.y
 }
 
 // SetX see IRelativePosition
 func (p *Position) SetX(percentx int) error {
-	if percentx > 100 {
-		percentx = 100
-	}
-	if percentx < -100 {
-		percentx = -100
-	}
-	p.x = percentx
+	p.x = Coord{Percent: clampPercent(float64(percentx))}
 	return nil
 }
 
 // SetY see IRelativePosition
 func (p *Position) SetY(percenty int) error {
-	if percenty > 100 {
-		percenty = 100
-	}
-	if percenty < -100 {
-		percenty = -100
-	}
-	p.y = percenty
+	p.y = Coord{Percent: clampPercent(float64(percenty))}
 	return nil
 }
 
@@ -87,22 +185,47 @@ func (p *Position) SetOffset(offset int) IRelativePosition {
 	return p
 }
 
+// GetAnchor see IRelativePosition
+func (p *Position) GetAnchor() Anchor {
+	return p.anchor
+}
+
+// SetAnchor see IRelativePosition
+func (p *Position) SetAnchor(anchor Anchor) IRelativePosition {
+	p.anchor = anchor
+	return p
+}
+
 // DistanceTo see IRelativePosition
 func (p *Position) DistanceTo(p2 IRelativePosition) IRelativePosition {
-	distance := CreatePos(p2.GetX()-p.GetX(), p2.GetY()-p.GetY())
+	x2, y2 := p2.Coords()
+	distance := CreateMixedPos(
+		Coord{Percent: x2.Percent - p.x.Percent, Cells: x2.Cells - p.x.Cells},
+		Coord{Percent: y2.Percent - p.y.Percent, Cells: y2.Cells - p.y.Cells},
+	)
 	return distance
 }
 
 // AddDistance see IRelativePosition
 func (p *Position) AddDistance(p2 IRelativePosition) IRelativePosition {
-	newpos := CreatePos(p.GetX()+p2.GetX(), p.GetY()+p2.GetY())
+	x2, y2 := p2.Coords()
+	newpos := CreateMixedPos(
+		Coord{Percent: p.x.Percent + x2.Percent, Cells: p.x.Cells + x2.Cells},
+		Coord{Percent: p.y.Percent + y2.Percent, Cells: p.y.Cells + y2.Cells},
+	)
 	newpos.SetOffset(p.GetOffset())
+	newpos.SetAnchor(p.GetAnchor())
 	return newpos
 }
 
 // MultiplyWith see IRelativePosition
 func (p *Position) MultiplyWith(factor float32) IRelativePosition {
-	newpos := CreatePos(int(float32(p.GetX())*factor), int(float32(p.GetY())*factor))
+	f := float64(factor)
+	newpos := CreateMixedPos(
+		Coord{Percent: p.x.Percent * f, Cells: int(float64(p.x.Cells) * f)},
+		Coord{Percent: p.y.Percent * f, Cells: int(float64(p.y.Cells) * f)},
+	)
 	newpos.SetOffset(p.GetOffset())
+	newpos.SetAnchor(p.GetAnchor())
 	return newpos
 }
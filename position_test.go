@@ -124,3 +124,109 @@ func TestPositionOffset(t *testing.T) {
 		t.Errorf("After reset offset = %d, want 0", pos.GetOffset())
 	}
 }
+
+func TestPositionAnchor(t *testing.T) {
+	pos := CreatePos(50, 50)
+	if pos.GetAnchor() != AnchorTopLeft {
+		t.Errorf("default anchor = %v, want AnchorTopLeft", pos.GetAnchor())
+	}
+
+	pos.SetAnchor(AnchorCenter)
+	if moved := pos.AddDistance(CreatePos(10, 0)); moved.GetAnchor() != AnchorCenter {
+		t.Error("AddDistance did not keep the anchor")
+	}
+	if scaled := pos.MultiplyWith(0.5); scaled.GetAnchor() != AnchorCenter {
+		t.Error("MultiplyWith did not keep the anchor")
+	}
+}
+
+func TestAnchorShift(t *testing.T) {
+	tests := []struct {
+		anchor         Anchor
+		shiftX, shiftY int
+	}{
+		{AnchorTopLeft, 0, 0},
+		{AnchorTop, -5, 0},
+		{AnchorTopRight, -10, 0},
+		{AnchorLeft, 0, -2},
+		{AnchorCenter, -5, -2},
+		{AnchorRight, -10, -2},
+		{AnchorBottomLeft, 0, -4},
+		{AnchorBottom, -5, -4},
+		{AnchorBottomRight, -10, -4},
+	}
+
+	for _, tt := range tests {
+		x, y := tt.anchor.shift(10, 4)
+		if x != tt.shiftX || y != tt.shiftY {
+			t.Errorf("Anchor(%d).shift(10, 4) = (%d, %d), want (%d, %d)", tt.anchor, x, y, tt.shiftX, tt.shiftY)
+		}
+	}
+}
+
+func TestParseCoord(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Coord
+		wantErr  bool
+	}{
+		{"50%", Coord{Percent: 50}, false},
+		{"12", Coord{Cells: 12}, false},
+		{"33.5%", Coord{Percent: 33.5}, false},
+		{"50% - 10", Coord{Percent: 50, Cells: -10}, false},
+		{"-5+100%+2", Coord{Percent: 100, Cells: -3}, false},
+		{"", Coord{}, true},
+		{"10px", Coord{}, true},
+		{"50%-", Coord{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseCoord(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCoord(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseCoord(%q) = %+v, want %+v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMixedPosition(t *testing.T) {
+	pos := CreateMixedPos(Coord{Percent: 50, Cells: -10}, Coord{Percent: 12.5})
+	x, y := pos.Coords()
+	if x.resolve(300) != 140 || y.resolve(80) != 10 {
+		t.Errorf("resolved to (%d, %d), want (140, 10)", x.resolve(300), y.resolve(80))
+	}
+
+	// fractions of a percent survive scaling, so a move covers every cell
+	step := CreatePos(0, 0).DistanceTo(CreatePos(1, 0)).MultiplyWith(0.5)
+	if x, _ := step.Coords(); x.Percent != 0.5 {
+		t.Errorf("MultiplyWith(0.5) of 1%% = %v%%, want 0.5%%", x.Percent)
+	}
+
+	moved := pos.AddDistance(CreateMixedPos(Coord{Percent: 0.5, Cells: 3}, Coord{}))
+	if x, _ := moved.Coords(); x != (Coord{Percent: 50.5, Cells: -7}) {
+		t.Errorf("AddDistance = %+v, want {50.5 -7}", x)
+	}
+}
+
+func TestCoordResolveRounding(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    int
+	}{
+		{percent: 0.5, want: 0},
+		{percent: -0.5, want: -1},
+		{percent: 1.5, want: 1},
+		{percent: -1.5, want: -2},
+		{percent: -1, want: -1},
+	}
+	for _, tt := range tests {
+		// 1% of 100 cells is one cell
+		if got := (Coord{Percent: tt.percent}).resolve(100); got != tt.want {
+			t.Errorf("resolve(%v%%) = %d, want %d", tt.percent, got, tt.want)
+		}
+	}
+}
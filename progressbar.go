@@ -0,0 +1,190 @@
+package animaterm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// eighthBlocks holds the partial block glyphs for one to seven eighths of a cell
+var eighthBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// ProgressBarStyle configures the look of a progress bar.
+// Fill and Empty default to "█" and "░". With Smooth the last filled cell is
+// drawn with an eighth-block glyph, giving the bar sub-cell precision; this
+// only applies with the default Fill glyph. Gradient shifts the color along
+// the bar using the same color steps as the gradients of DrawPattern.
+// Color is a palette index, DEFAULTCOLOR draws the bar in the terminal's
+// default foreground color. Start from DefaultProgressBarStyle to keep it.
+type ProgressBarStyle struct {
+	Color       int
+	Fill        string
+	Empty       string
+	Smooth      bool
+	Gradient    bool
+	ShowPercent bool
+	ShowETA     bool
+}
+
+// DefaultProgressBarStyle draws the bar in the terminal's default
+// foreground color, which stays visible on light and dark terminals
+var DefaultProgressBarStyle = ProgressBarStyle{Color: DEFAULTCOLOR}
+
+// ProgressBar implements IProgressBar as a single row of the UserInterface
+type ProgressBar struct {
+	ui       IUserInterface
+	bounds   Rect
+	label    string
+	style    ProgressBarStyle
+	progress float64
+	started  time.Time
+	now      func() time.Time
+	mutex    sync.Mutex
+}
+
+// CreateProgressBar creates a progress bar at pos spanning percentWidth of the
+// frame, including label, percentage and ETA. The ETA is estimated from the
+// time elapsed since the bar was created.
+func CreateProgressBar(ui IUserInterface, pos IRelativePosition, percentWidth int, label string, style ProgressBarStyle) IProgressBar {
+	bounds := percentRect(ui, pos, percentWidth, 0)
+	bounds.Height = 1
+	return newProgressBar(ui, bounds, label, style)
+}
+
+func newProgressBar(ui IUserInterface, bounds Rect, label string, style ProgressBarStyle) *ProgressBar {
+	if style.Fill == "" {
+		style.Fill = "█"
+	}
+	if style.Empty == "" {
+		style.Empty = "░"
+	}
+	pb := &ProgressBar{
+		ui:      ui,
+		bounds:  bounds,
+		label:   label,
+		style:   style,
+		started: time.Now(),
+		now:     time.Now,
+	}
+	pb.Redraw()
+	return pb
+}
+
+// SetProgress see IProgressBar
+func (pb *ProgressBar) SetProgress(progress float64) {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 1 {
+		progress = 1
+	}
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	pb.progress = progress
+	pb.draw()
+}
+
+// Progress see IProgressBar
+func (pb *ProgressBar) Progress() float64 {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	return pb.progress
+}
+
+// SetLabel see IProgressBar
+func (pb *ProgressBar) SetLabel(label string) {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	pb.label = label
+	pb.draw()
+}
+
+// Redraw see IProgressBar
+func (pb *ProgressBar) Redraw() {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	pb.draw()
+}
+
+// SetBounds see ILayoutNode, the bar only uses the first row of bounds
+func (pb *ProgressBar) SetBounds(bounds Rect) {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	bounds.Height = 1
+	pb.bounds = bounds
+	pb.draw()
+}
+
+// suffix returns the percentage and ETA text shown right of the bar
+func (pb *ProgressBar) suffix() string {
+	suffix := ""
+	if pb.style.ShowPercent {
+		suffix += fmt.Sprintf(" %3d%%", int(pb.progress*100))
+	}
+	if pb.style.ShowETA {
+		suffix += " ETA " + pb.eta()
+	}
+	return suffix
+}
+
+// eta estimates the remaining time assuming a constant rate of progress
+func (pb *ProgressBar) eta() string {
+	if pb.progress <= 0 {
+		return "--:--"
+	}
+	elapsed := pb.now().Sub(pb.started)
+	remaining := time.Duration(float64(elapsed) / pb.progress * (1 - pb.progress))
+	seconds := int(remaining.Round(time.Second).Seconds())
+	if seconds >= 100*60 {
+		return "--:--"
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// draw renders label, bar and suffix. The caller must hold the mutex.
+func (pb *ProgressBar) draw() {
+	x, y := pb.bounds.X, pb.bounds.Y
+	if pb.label != "" {
+		x += pb.ui.DrawClipped(pb.bounds, x, y, pb.label+" ", ALREADYCOLORED)
+	}
+	suffix := pb.suffix()
+	width := pb.bounds.X + pb.bounds.Width - x - len(suffix)
+	for i, cell := range progressCells(pb.progress, width, pb.style) {
+		color := pb.style.Color
+		if color == DEFAULTCOLOR {
+			color = ALREADYCOLORED
+		} else if pb.style.Gradient && width > 1 {
+			color = gradientStep(color, float32(i)/float32(width-1))
+		}
+		pb.ui.DrawClipped(pb.bounds, x+i, y, cell, color)
+	}
+	if width > 0 {
+		x += width
+	}
+	pb.ui.DrawClipped(pb.bounds, x, y, suffix, WHITE)
+}
+
+// progressCells returns the glyph of every cell of a bar with the given width
+func progressCells(progress float64, width int, style ProgressBarStyle) []string {
+	if width <= 0 {
+		return nil
+	}
+	cells := make([]string, width)
+	filled := progress * float64(width)
+	whole := int(filled)
+	partial := 0
+	if style.Smooth && style.Fill == "█" {
+		partial = int((filled - float64(whole)) * 8)
+	}
+	for i := range cells {
+		switch {
+		case i < whole:
+			cells[i] = style.Fill
+		case i == whole && partial > 0:
+			cells[i] = eighthBlocks[partial]
+		default:
+			cells[i] = style.Empty
+		}
+	}
+	return cells
+}
@@ -0,0 +1,84 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress float64
+		style    ProgressBarStyle
+		expected string
+	}{
+		{"Empty", 0, ProgressBarStyle{Fill: "█", Empty: "░"}, "░░░░░░░░"},
+		{"Half", 0.5, ProgressBarStyle{Fill: "█", Empty: "░"}, "████░░░░"},
+		{"Full", 1, ProgressBarStyle{Fill: "█", Empty: "░"}, "████████"},
+		{"Custom glyphs", 0.25, ProgressBarStyle{Fill: "=", Empty: "-"}, "==------"},
+		{"Eighths", 0.5 + 3.0/64, ProgressBarStyle{Fill: "█", Empty: " ", Smooth: true}, "████▍   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := strings.Join(progressCells(tt.progress, 8, tt.style), "")
+			if result != tt.expected {
+				t.Errorf("progressCells(%v) = %q, want %q", tt.progress, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProgressBarETA(t *testing.T) {
+	ui := CreateUI()
+	pb := CreateProgressBar(ui, CreatePos(0, 0), 100, "build", ProgressBarStyle{ShowPercent: true, ShowETA: true}).(*ProgressBar)
+	pb.now = func() time.Time { return pb.started.Add(30 * time.Second) }
+
+	if suffix := pb.suffix(); suffix != "   0% ETA --:--" {
+		t.Errorf("suffix() = %q before any progress", suffix)
+	}
+
+	pb.SetProgress(0.25)
+	if suffix := pb.suffix(); suffix != "  25% ETA 01:30" {
+		t.Errorf("suffix() = %q, want %q", suffix, "  25% ETA 01:30")
+	}
+
+	pb.SetProgress(2)
+	if pb.Progress() != 1 {
+		t.Errorf("Progress() = %v, want it clamped to 1", pb.Progress())
+	}
+}
+
+func TestProgressBarDefaultColor(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	CreateProgressBar(ui, CreatePos(0, 0), 10, "", DefaultProgressBarStyle)
+	if got := ui.pixels[0][0]; got != "░" {
+		t.Errorf("bar with the default style = %q, want it uncolored", got)
+	}
+
+	style := DefaultProgressBarStyle
+	style.Color = BLACK
+	CreateProgressBar(ui, CreatePos(0, 50), 10, "", style)
+	if got, want := ui.pixels[12][0], Color("░", BLACK); got != want {
+		t.Errorf("black bar = %q, want %q", got, want)
+	}
+}
+
+func TestProgressBarLabel(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	pb := CreateProgressBar(ui, CreatePos(0, 0), 20, "[1]", DefaultProgressBarStyle)
+	if got := row(ui, 0, 0, 3); got != "[1]" {
+		t.Errorf("plain label = %q, want it drawn literally", got)
+	}
+
+	pb.SetLabel(Markup("[red]ok[/]"))
+	if got := stripANSI(row(ui, 0, 0, 2)); got != "ok" {
+		t.Errorf("markup label = %q, want %q", got, "ok")
+	}
+	if got := ui.styleAt(0, 0).Fg; got != RED {
+		t.Errorf("markup label color = %d, want %d", got, RED)
+	}
+}
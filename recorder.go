@@ -0,0 +1,135 @@
+package animaterm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// asciicastHeader is the first line of an asciicast v2 file
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder implements IRecorder. It is put in front of the output of a user
+// interface and passes everything through while recording it.
+type Recorder struct {
+	ui      IUserInterface
+	output  io.Writer
+	cast    io.Writer
+	start   time.Time
+	now     func() time.Time
+	pending []byte
+	detach  func()
+	err     error
+	stopped bool
+	mutex   sync.Mutex
+}
+
+// CreateRecorder starts recording everything ui renders as an asciicast v2
+// to w. The header takes the size of ui, title may be empty. Resizes are
+// recorded as well. The output of ui is still written as before.
+func CreateRecorder(ui IUserInterface, w io.Writer, title string) (IRecorder, error) {
+	r := &Recorder{
+		ui:     ui,
+		output: ui.Output(),
+		cast:   w,
+		start:  time.Now(),
+		now:    time.Now,
+	}
+	width, height := ui.Size()
+	header := asciicastHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Title:     title,
+		Env:       map[string]string{},
+	}
+	for _, name := range []string{"SHELL", "TERM"} {
+		if value := os.Getenv(name); value != "" {
+			header.Env[name] = value
+		}
+	}
+	if err := r.writeLine(header); err != nil {
+		return nil, fmt.Errorf("writing asciicast header: %w", err)
+	}
+	ui.SetOutput(r)
+	r.detach = ui.OnResize(func(width int, height int) {
+		r.event("r", fmt.Sprintf("%dx%d", width, height))
+	})
+	return r, nil
+}
+
+// Write passes p on to the output of the user interface and records it. A
+// character cut in two is recorded with the write completing it.
+func (r *Recorder) Write(p []byte) (int, error) {
+	n, err := r.output.Write(p)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	data := append(r.pending, p[:n]...)
+	r.pending = nil
+	for cut := 1; cut < utf8.UTFMax && cut <= len(data); cut++ {
+		if utf8.RuneStart(data[len(data)-cut]) {
+			if !utf8.FullRune(data[len(data)-cut:]) {
+				r.pending = append([]byte(nil), data[len(data)-cut:]...)
+				data = data[:len(data)-cut]
+			}
+			break
+		}
+	}
+	r.record("o", string(data))
+	return n, err
+}
+
+// Stop see IRecorder
+func (r *Recorder) Stop() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.stopped {
+		// a character still cut in two is recorded as it is
+		r.record("o", string(r.pending))
+		r.pending = nil
+		r.stopped = true
+		r.ui.SetOutput(r.output)
+		r.detach()
+	}
+	return r.err
+}
+
+// event records data of type kind ("o" for output, "r" for resize)
+func (r *Recorder) event(kind string, data string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.record(kind, data)
+}
+
+// record writes an event. The first error is kept and returned by Stop,
+// rendering goes on regardless. The caller must hold the mutex.
+func (r *Recorder) record(kind string, data string) {
+	if r.stopped || r.err != nil || data == "" {
+		return
+	}
+	elapsed := math.Round(r.now().Sub(r.start).Seconds()*1e6) / 1e6
+	r.err = r.writeLine([]any{elapsed, kind, data})
+}
+
+// writeLine writes v as a single line of JSON
+func (r *Recorder) writeLine(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = r.cast.Write(append(line, '\n'))
+	return err
+}
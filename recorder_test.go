@@ -0,0 +1,158 @@
+package animaterm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingWriter fails every write after the first ok ones
+type failingWriter struct {
+	ok int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.ok == 0 {
+		return 0, errors.New("disk full")
+	}
+	w.ok--
+	return len(p), nil
+}
+
+func TestRecorder(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	var terminal, cast bytes.Buffer
+	ui.SetOutput(&terminal)
+	width, height := ui.Size()
+
+	rec, err := CreateRecorder(ui, &cast, "demo")
+	if err != nil {
+		t.Fatalf("CreateRecorder returned error: %v", err)
+	}
+	r := rec.(*Recorder)
+	r.now = func() time.Time { return r.start.Add(1500 * time.Millisecond) }
+
+	_ = ui.moveCursorTo(3, 2)
+	_ = ui.initPixels(5, 5)
+	ui.checkResize()
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	_, _ = fmt.Fprint(ui.Output(), "after")
+
+	if got, want := terminal.String(), "\033[2;3H\033[2Jafter"; got != want {
+		t.Errorf("terminal got %q, want %q", got, want)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(cast.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("recorded %d lines, want 4:\n%s", len(lines), cast.String())
+	}
+	var header asciicastHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header is not JSON: %v", err)
+	}
+	if header.Version != 2 || header.Width != width || header.Height != height || header.Title != "demo" || header.Timestamp == 0 {
+		t.Errorf("header = %+v", header)
+	}
+	want := []string{
+		`[1.5,"o","\u001b[2;3H"]`,
+		`[1.5,"o","\u001b[2J"]`,
+		fmt.Sprintf(`[1.5,"r","%dx%d"]`, width, height),
+	}
+	for i, line := range lines[1:] {
+		if line != want[i] {
+			t.Errorf("event %d = %s, want %s", i, line, want[i])
+		}
+	}
+}
+
+func TestRecorderSplitCharacter(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	var terminal, cast bytes.Buffer
+	ui.SetOutput(&terminal)
+	rec, err := CreateRecorder(ui, &cast, "")
+	if err != nil {
+		t.Fatalf("CreateRecorder returned error: %v", err)
+	}
+	// 世 is e4 b8 96
+	for _, write := range []string{"a\xe4", "\xb8", "\x96b"} {
+		_, _ = ui.Output().Write([]byte(write))
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	recorded, err := ParseAsciicast(&cast)
+	if err != nil {
+		t.Fatalf("ParseAsciicast returned error: %v", err)
+	}
+	output := ""
+	for _, event := range recorded.Events {
+		output += event.Data
+	}
+	if output != "a世b" || len(recorded.Events) != 2 {
+		t.Errorf("recorded %d events %q, want \"a\" and \"世b\"", len(recorded.Events), output)
+	}
+	if terminal.String() != "a世b" {
+		t.Errorf("terminal got %q", terminal.String())
+	}
+}
+
+func TestRecorderStop(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	var terminal, cast bytes.Buffer
+	ui.SetOutput(&terminal)
+	handlers := len(ui.resizeHandlers)
+	rec, err := CreateRecorder(ui, &cast, "")
+	if err != nil {
+		t.Fatalf("CreateRecorder returned error: %v", err)
+	}
+	// the second half of 世 never arrives
+	_, _ = ui.Output().Write([]byte("a\xe4\xb8"))
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if len(ui.resizeHandlers) != handlers {
+		t.Errorf("%d resize handlers after Stop, want %d", len(ui.resizeHandlers), handlers)
+	}
+	_ = ui.initPixels(5, 5)
+	ui.checkResize()
+
+	recorded, err := ParseAsciicast(&cast)
+	if err != nil {
+		t.Fatalf("ParseAsciicast returned error: %v", err)
+	}
+	if len(recorded.Events) != 2 || recorded.Events[0].Data != "a" || recorded.Events[1].Data == "" {
+		t.Errorf("recorded %+v, want the text and the cut character, but no resize", recorded.Events)
+	}
+}
+
+func TestRecorderWriteError(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	var terminal bytes.Buffer
+	ui.SetOutput(&terminal)
+	if _, err := CreateRecorder(ui, &failingWriter{}, ""); err == nil {
+		t.Error("CreateRecorder should fail when the header cannot be written")
+	}
+	if ui.Output() != &terminal {
+		t.Error("failed CreateRecorder replaced the output")
+	}
+
+	rec, err := CreateRecorder(ui, &failingWriter{ok: 1}, "")
+	if err != nil {
+		t.Fatalf("CreateRecorder returned error: %v", err)
+	}
+	_ = ui.moveCursorTo(1, 1)
+	_ = ui.moveCursorTo(2, 2)
+	if terminal.String() != "\033[1;1H\033[2;2H" {
+		t.Errorf("rendering stopped after the recording failed: %q", terminal.String())
+	}
+	if err := rec.Stop(); err == nil {
+		t.Error("Stop should return the error of the recording")
+	}
+}
@@ -0,0 +1,29 @@
+package animaterm
+
+// Rect describes a rectangular region of the terminal in absolute cells.
+// X and Y address the top-left cell, Width and Height its extent.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Contains reports whether the cell at x and y lies inside the rectangle
+func (r Rect) Contains(x int, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// percentRect converts a position and a percentage based extent into an
+// absolute rectangle inside the frame of ui. The offset of pos is added
+// to the resulting row.
+func percentRect(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int) Rect {
+	x, y := ui.PositionToAbsolute(pos)
+	endX, endY := ui.PositionToAbsolute(pos.AddDistance(CreatePos(percentWidth, percentHeight)))
+	return Rect{
+		X:      x,
+		Y:      y,
+		Width:  endX - x,
+		Height: endY - y,
+	}
+}
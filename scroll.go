@@ -0,0 +1,71 @@
+package animaterm
+
+// Characters of the scrollbar drawn at the right edge of scrolled widgets
+const (
+	ScrollbarTrack = "░"
+	ScrollbarThumb = "█"
+)
+
+// clampOffset limits the index of the first visible row so that the view
+// never scrolls past the last of total rows
+func clampOffset(offset int, total int, visible int) int {
+	if offset > total-visible {
+		offset = total - visible
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
+// revealOffset returns the offset closest to offset that shows row
+func revealOffset(row int, offset int, visible int) int {
+	if row < 0 || visible < 1 {
+		return offset
+	}
+	if row < offset {
+		return row
+	}
+	if row >= offset+visible {
+		return row - visible + 1
+	}
+	return offset
+}
+
+// moveSelection moves selected by delta rows within total rows. Without a
+// selection moving down starts at the first row and moving up at the last.
+func moveSelection(selected int, delta int, total int) int {
+	if total == 0 {
+		return -1
+	}
+	switch {
+	case selected < 0 && delta > 0:
+		selected = delta - 1
+	case selected < 0:
+		selected = total + delta
+	default:
+		selected += delta
+	}
+	return max(0, min(selected, total-1))
+}
+
+// scrollbar returns a column of height cells showing which part of total
+// rows is visible when the view starts at offset
+func scrollbar(height int, total int, offset int) []string {
+	if height < 1 {
+		return nil
+	}
+	cells := make([]string, height)
+	thumb := max(1, height*height/max(total, 1))
+	start := 0
+	if total > height {
+		start = (height - thumb) * offset / (total - height)
+	}
+	for i := range cells {
+		cells[i] = ScrollbarTrack
+		if i >= start && i < start+thumb {
+			cells[i] = ScrollbarThumb
+		}
+	}
+	return cells
+}
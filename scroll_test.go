@@ -0,0 +1,31 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScrollbar(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+		total  int
+		offset int
+		want   string
+	}{
+		{name: "everything visible", height: 4, total: 3, offset: 0, want: "████"},
+		{name: "top", height: 4, total: 8, offset: 0, want: "██░░"},
+		{name: "bottom", height: 4, total: 8, offset: 4, want: "░░██"},
+		{name: "middle", height: 4, total: 16, offset: 6, want: "░█░░"},
+		{name: "minimal thumb", height: 2, total: 1000, offset: 500, want: "█░"},
+		{name: "no room", height: 0, total: 8, offset: 0, want: ""},
+		{name: "negative height", height: -1, total: 8, offset: 0, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(scrollbar(tt.height, tt.total, tt.offset), ""); got != tt.want {
+				t.Errorf("scrollbar(%d, %d, %d) = %q, want %q", tt.height, tt.total, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
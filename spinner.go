@@ -0,0 +1,152 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpinnerFrames is a set of frames shown in a loop, each for Interval
+type SpinnerFrames struct {
+	Frames   []string
+	Interval time.Duration
+}
+
+// Built-in frame sets for spinners
+var (
+	SpinnerDots = SpinnerFrames{
+		Frames:   []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		Interval: 80 * time.Millisecond,
+	}
+	SpinnerLine = SpinnerFrames{
+		Frames:   []string{"-", "\\", "|", "/"},
+		Interval: 130 * time.Millisecond,
+	}
+	SpinnerBraille = SpinnerFrames{
+		Frames:   []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"},
+		Interval: 80 * time.Millisecond,
+	}
+	SpinnerArc = SpinnerFrames{
+		Frames:   []string{"◜", "◠", "◝", "◞", "◡", "◟"},
+		Interval: 100 * time.Millisecond,
+	}
+	SpinnerBouncingBar = SpinnerFrames{
+		Frames: []string{
+			"[    ]", "[=   ]", "[==  ]", "[=== ]", "[ ===]", "[  ==]", "[   =]",
+			"[    ]", "[   =]", "[  ==]", "[ ===]", "[====]", "[=== ]", "[==  ]", "[=   ]",
+		},
+		Interval: 80 * time.Millisecond,
+	}
+)
+
+// Spinner implements ISpinner and ITicker
+type Spinner struct {
+	ui       IUserInterface
+	bounds   Rect
+	frames   SpinnerFrames
+	label    string
+	color    int
+	frame    int
+	last     time.Time
+	symbol   string
+	finished bool
+	drawn    int
+	mutex    sync.Mutex
+}
+
+// CreateSpinner creates a spinner at pos and registers it with the draw loop
+// of ui. An empty frame set falls back to SpinnerDots.
+func CreateSpinner(ui IUserInterface, pos IRelativePosition, frames SpinnerFrames, label string, color int) ISpinner {
+	if len(frames.Frames) == 0 {
+		frames = SpinnerDots
+	}
+	if frames.Interval <= 0 {
+		frames.Interval = SpinnerDots.Interval
+	}
+	bounds := percentRect(ui, pos, 0, 0)
+	bounds.Width = ui.PercentToAbsoluteXPostion(100) - bounds.X
+	bounds.Height = 1
+	s := &Spinner{
+		ui:     ui,
+		bounds: bounds,
+		frames: frames,
+		label:  label,
+		color:  color,
+		last:   time.Now(),
+	}
+	s.draw()
+	ui.AddTicker(s)
+	return s
+}
+
+// Tick see ITicker
+func (s *Spinner) Tick(now time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.finished {
+		return false
+	}
+	if now.Sub(s.last) >= s.frames.Interval {
+		s.frame = (s.frame + 1) % len(s.frames.Frames)
+		s.last = now
+		s.draw()
+	}
+	return true
+}
+
+// SetLabel see ISpinner
+func (s *Spinner) SetLabel(label string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.label = label
+	s.draw()
+}
+
+// SetBounds see ILayoutNode, the spinner only uses the first row of bounds
+func (s *Spinner) SetBounds(bounds Rect) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	bounds.Height = 1
+	s.bounds = bounds
+	s.drawn = 0
+	s.draw()
+}
+
+// Success see ISpinner
+func (s *Spinner) Success(message string) {
+	s.finish("[success]✔[/]", MARKUP, message)
+}
+
+// Fail see ISpinner
+func (s *Spinner) Fail(message string) {
+	s.finish("[error]✖[/]", MARKUP, message)
+}
+
+func (s *Spinner) finish(symbol string, color int, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.finished = true
+	s.symbol = symbol
+	s.color = color
+	if message != "" {
+		s.label = message
+	}
+	s.draw()
+}
+
+// draw renders the current frame (or the final symbol) and the label, erasing
+// what is left of a previously longer label. The caller must hold the mutex.
+func (s *Spinner) draw() {
+	x, y := s.bounds.X, s.bounds.Y
+	glyph := s.frames.Frames[s.frame]
+	if s.finished {
+		// pad the symbol so the label does not jump
+		glyph = s.symbol + strings.Repeat(" ", max(displayWidth(glyph)-1, 0))
+	}
+	x += s.ui.DrawClipped(s.bounds, x, y, glyph, s.color)
+	x += s.ui.DrawClipped(s.bounds, x, y, " "+s.label, ALREADYCOLORED)
+	if rest := s.drawn - (x - s.bounds.X); rest > 0 {
+		s.ui.DrawClipped(s.bounds, x, y, strings.Repeat(" ", rest), BLANK)
+	}
+	s.drawn = x - s.bounds.X
+}
@@ -0,0 +1,56 @@
+package animaterm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpinnerTick(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	s := CreateSpinner(ui, CreatePos(0, 0), SpinnerLine, "working", ALREADYCOLORED).(*Spinner)
+
+	if ui.pixels[0][0] != "-" {
+		t.Errorf("first frame = %q, want %q", ui.pixels[0][0], "-")
+	}
+
+	start := s.last
+	if !s.Tick(start.Add(time.Millisecond)) || ui.pixels[0][0] != "-" {
+		t.Errorf("spinner advanced before its interval elapsed: %q", ui.pixels[0][0])
+	}
+	s.Tick(start.Add(SpinnerLine.Interval))
+	if ui.pixels[0][0] != "\\" {
+		t.Errorf("second frame = %q, want %q", ui.pixels[0][0], "\\")
+	}
+}
+
+func TestSpinnerFinish(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	s := CreateSpinner(ui, CreatePos(0, 0), SpinnerBouncingBar, "a long running task", RED).(*Spinner)
+
+	s.Fail("failed")
+	if s.Tick(time.Now().Add(time.Hour)) {
+		t.Error("Tick() should return false once the spinner is finished")
+	}
+	if ui.pixels[0][0] != Color("✖", RED2) {
+		t.Errorf("symbol = %q, want a red cross", ui.pixels[0][0])
+	}
+	// "[    ] a long running task" shrinks to "✖      failed"
+	if ui.pixels[0][13] != " " || ui.pixels[0][20] != " " {
+		t.Error("remainder of the previous label was not erased")
+	}
+
+	ui.tickers = []ITicker{s}
+	ui.runTickers(time.Now())
+	if len(ui.tickers) != 0 {
+		t.Errorf("finished spinner still registered: %d tickers", len(ui.tickers))
+	}
+}
+
+func TestSpinnerLabel(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	CreateSpinner(ui, CreatePos(0, 0), SpinnerLine, "[error] disk full", ALREADYCOLORED)
+	if got := row(ui, 0, 2, 7); got != "[error]" {
+		t.Errorf("label = %q, want it drawn literally", got)
+	}
+}
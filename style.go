@@ -0,0 +1,315 @@
+package animaterm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DEFAULTCOLOR selects the terminal's default foreground or background color in a Style
+const DEFAULTCOLOR int = -1
+
+// Style describes how a cell is rendered. Fg and Bg are palette indices
+// (0-255) or DEFAULTCOLOR.
+type Style struct {
+	Fg        int
+	Bg        int
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Blink     bool
+	Reverse   bool
+	Strike    bool
+}
+
+// DefaultStyle renders cells in the terminal's default colors without attributes
+var DefaultStyle = Style{Fg: DEFAULTCOLOR, Bg: DEFAULTCOLOR}
+
+// sgr returns the escape sequence selecting the style, or an empty string
+// for the default style
+func (s Style) sgr() string {
+	params := []string{}
+	for _, attr := range []struct {
+		set  bool
+		code string
+	}{
+		{s.Bold, "1"}, {s.Dim, "2"}, {s.Italic, "3"}, {s.Underline, "4"},
+		{s.Blink, "5"}, {s.Reverse, "7"}, {s.Strike, "9"},
+	} {
+		if attr.set {
+			params = append(params, attr.code)
+		}
+	}
+	if s.Fg >= 0 && s.Fg < 256 {
+		params = append(params, fmt.Sprintf("38;5;%03d", s.Fg))
+	}
+	if s.Bg >= 0 && s.Bg < 256 {
+		params = append(params, fmt.Sprintf("48;5;%03d", s.Bg))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\033[" + strings.Join(params, ";") + "m"
+}
+
+// Render wraps text in the escape sequences of the style
+func (s Style) Render(text string) string {
+	sgr := s.sgr()
+	if sgr == "" {
+		return text
+	}
+	return sgr + text + getControlSequence(RESET)
+}
+
+// over returns s on top of base: colors s leaves unset show those of base,
+// attributes of both apply
+func (s Style) over(base Style) Style {
+	if s.Fg == DEFAULTCOLOR {
+		s.Fg = base.Fg
+	}
+	if s.Bg == DEFAULTCOLOR {
+		s.Bg = base.Bg
+	}
+	s.Bold = s.Bold || base.Bold
+	s.Dim = s.Dim || base.Dim
+	s.Italic = s.Italic || base.Italic
+	s.Underline = s.Underline || base.Underline
+	s.Blink = s.Blink || base.Blink
+	s.Reverse = s.Reverse || base.Reverse
+	s.Strike = s.Strike || base.Strike
+	return s
+}
+
+// renderOver renders text containing escape sequences on top of base, so
+// that base shows wherever the sequences of text leave a style unset,
+// including after a reset
+func renderOver(base Style, text string) string {
+	var sb strings.Builder
+	for _, cluster := range newANSIParser().parse(text) {
+		sb.WriteString(cluster.style.over(base).Render(cluster.text))
+	}
+	return sb.String()
+}
+
+// styledCluster is a grapheme cluster together with the style it is drawn in
+type styledCluster struct {
+	text  string
+	style Style
+}
+
+// ansiParser splits text containing escape sequences into styled clusters.
+// Select Graphic Rendition sequences update the current style, which carries
+// over from one parsed line to the next; all other sequences are dropped.
+type ansiParser struct {
+	style Style
+}
+
+func newANSIParser() *ansiParser {
+	return &ansiParser{style: DefaultStyle}
+}
+
+// parse returns the visible clusters of line with their styles
+func (ap *ansiParser) parse(line string) []styledCluster {
+	clusters := []styledCluster{}
+	flush := func(text string) {
+		for _, cluster := range graphemes(text) {
+			clusters = append(clusters, styledCluster{text: cluster, style: ap.style})
+		}
+	}
+	start := 0
+	for i := 0; i < len(line); {
+		if line[i] != '\033' {
+			i++
+			continue
+		}
+		flush(line[start:i])
+		length, final, params := scanEscape(line[i:])
+		if final == 'm' {
+			ap.apply(params)
+		}
+		i += length
+		start = i
+	}
+	flush(line[start:])
+	return clusters
+}
+
+// scanEscape measures the escape sequence at the start of s. For control
+// sequences (ESC [) it also returns the final byte and the parameters.
+func scanEscape(s string) (int, byte, string) {
+	if len(s) < 2 {
+		return len(s), 0, ""
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1, s[i], s[2:i]
+			}
+		}
+		return len(s), 0, ""
+	case ']':
+		// operating system command, terminated by BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1, 0, ""
+			}
+			if s[i] == '\033' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, 0, ""
+			}
+		}
+		return len(s), 0, ""
+	default:
+		return 2, 0, ""
+	}
+}
+
+// apply updates the current style with the parameters of an SGR sequence
+func (ap *ansiParser) apply(params string) {
+	codes := []int{}
+	for _, param := range strings.Split(strings.ReplaceAll(params, ":", ";"), ";") {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			code = 0
+		}
+		codes = append(codes, code)
+	}
+	s := &ap.style
+	for i := 0; i < len(codes); i++ {
+		switch code := codes[i]; {
+		case code == 0:
+			*s = DefaultStyle
+		case code == 1:
+			s.Bold = true
+		case code == 2:
+			s.Dim = true
+		case code == 3:
+			s.Italic = true
+		case code == 4:
+			s.Underline = true
+		case code == 5:
+			s.Blink = true
+		case code == 7:
+			s.Reverse = true
+		case code == 9:
+			s.Strike = true
+		case code == 22:
+			s.Bold, s.Dim = false, false
+		case code == 23:
+			s.Italic = false
+		case code == 24:
+			s.Underline = false
+		case code == 25:
+			s.Blink = false
+		case code == 27:
+			s.Reverse = false
+		case code == 29:
+			s.Strike = false
+		case code >= 30 && code <= 37:
+			s.Fg = code - 30
+		case code == 38 || code == 48:
+			color, used := extendedColor(codes[i+1:])
+			i += used
+			if color == DEFAULTCOLOR {
+				break
+			}
+			if code == 38 {
+				s.Fg = color
+			} else {
+				s.Bg = color
+			}
+		case code == 39:
+			s.Fg = DEFAULTCOLOR
+		case code >= 40 && code <= 47:
+			s.Bg = code - 40
+		case code == 49:
+			s.Bg = DEFAULTCOLOR
+		case code >= 90 && code <= 97:
+			s.Fg = code - 90 + 8
+		case code >= 100 && code <= 107:
+			s.Bg = code - 100 + 8
+		}
+	}
+}
+
+// extendedColor decodes the arguments following 38 or 48: either "5;n" for a
+// palette index or "2;r;g;b" for a true color, which is mapped onto the
+// closest palette entry. Returns the color and the number of codes consumed.
+func extendedColor(codes []int) (int, int) {
+	switch {
+	case len(codes) >= 2 && codes[0] == 5:
+		return codes[1] & 0xff, 2
+	case len(codes) >= 4 && codes[0] == 2:
+		return rgbToPalette(codes[1], codes[2], codes[3]), 4
+	default:
+		return DEFAULTCOLOR, len(codes)
+	}
+}
+
+// cubeLevels are the channel intensities of the 6x6x6 color cube of the palette
+var cubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// rgbToPalette returns the palette index of the cube color or grey closest to r, g and b
+func rgbToPalette(r int, g int, b int) int {
+	nearestLevel := func(v int) int {
+		best := 0
+		for i, level := range cubeLevels {
+			if abs(level-v) < abs(cubeLevels[best]-v) {
+				best = i
+			}
+		}
+		return best
+	}
+	distance := func(r2 int, g2 int, b2 int) int {
+		return (r-r2)*(r-r2) + (g-g2)*(g-g2) + (b-b2)*(b-b2)
+	}
+
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	index := 16 + 36*ri + 6*gi + bi
+	best := distance(cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	grey := (r + g + b) / 3
+	step := min(max((grey-8+5)/10, 0), 23)
+	if level := 8 + 10*step; distance(level, level, level) < best {
+		index = 232 + step
+	}
+	return index
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// carryStyles prefixes every line with the style the escape sequences of the
+// lines before it left active, so that each line can be drawn on its own
+func carryStyles(lines []string) []string {
+	parser := newANSIParser()
+	carried := make([]string, len(lines))
+	for k, line := range lines {
+		carried[k] = parser.style.sgr() + line
+		parser.parse(line)
+	}
+	return carried
+}
+
+// stripANSI removes all escape sequences from s
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\033") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			length, _, _ := scanEscape(s[i:])
+			i += length
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
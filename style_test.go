@@ -0,0 +1,90 @@
+package animaterm
+
+import (
+	"testing"
+)
+
+func TestStyleRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{"Default", DefaultStyle, "x"},
+		{"Foreground", Style{Fg: RED, Bg: DEFAULTCOLOR}, "\033[38;5;001mx\033[0m"},
+		{"Bold on background", Style{Fg: DEFAULTCOLOR, Bg: BLUE, Bold: true}, "\033[1;48;5;004mx\033[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.style.Render("x"); result != tt.expected {
+				t.Errorf("Render() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestANSIParser(t *testing.T) {
+	parser := newANSIParser()
+	clusters := parser.parse("a\033[1;31mb\033[38;5;82;48;2;255;0;0mc\033[22;39md\033]0;title\ae")
+
+	expected := []styledCluster{
+		{"a", DefaultStyle},
+		{"b", Style{Fg: RED, Bg: DEFAULTCOLOR, Bold: true}},
+		{"c", Style{Fg: COLORPATTERNLIME, Bg: 196, Bold: true}},
+		{"d", Style{Fg: DEFAULTCOLOR, Bg: 196}},
+		{"e", Style{Fg: DEFAULTCOLOR, Bg: 196}},
+	}
+	if len(clusters) != len(expected) {
+		t.Fatalf("parse() returned %d clusters, want %d: %+v", len(clusters), len(expected), clusters)
+	}
+	for i := range expected {
+		if clusters[i] != expected[i] {
+			t.Errorf("cluster %d = %+v, want %+v", i, clusters[i], expected[i])
+		}
+	}
+
+	// the style carries over to the next line until it is reset
+	if next := parser.parse("f\033[0mg"); next[0].style.Bg != 196 || next[1].style != DefaultStyle {
+		t.Errorf("style not carried over: %+v", next)
+	}
+}
+
+func TestRGBToPalette(t *testing.T) {
+	tests := []struct {
+		r, g, b  int
+		expected int
+	}{
+		{0, 0, 0, 16},
+		{255, 255, 255, 231},
+		{255, 0, 0, 196},
+		{95, 135, 0, 64},
+		{128, 128, 128, 244},
+	}
+
+	for _, tt := range tests {
+		if result := rgbToPalette(tt.r, tt.g, tt.b); result != tt.expected {
+			t.Errorf("rgbToPalette(%d, %d, %d) = %d, want %d", tt.r, tt.g, tt.b, result, tt.expected)
+		}
+	}
+}
+
+func TestDrawElementPreColored(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.SetBorder(0)
+
+	text := "\033[31mab\ncd\033[0m"
+	ui.DrawElement(CreatePos(0, 0), text, ALREADYCOLORED)
+	red := Style{Fg: RED, Bg: DEFAULTCOLOR}
+	if ui.pixels[0][0] != red.Render("a") || ui.pixels[1][1] != red.Render("d") {
+		t.Errorf("pre-colored cells = %q, %q", ui.pixels[0][0], ui.pixels[1][1])
+	}
+	if ui.pixels[0][2] != " " {
+		t.Errorf("escape sequence leaked into the grid: %q", ui.pixels[0][2])
+	}
+
+	ui.DrawElement(CreatePos(0, 0), text, BLANK)
+	if got := row(ui, 0, 0, 3) + row(ui, 1, 0, 3); got != "      " {
+		t.Errorf("erase left %q behind", got)
+	}
+}
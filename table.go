@@ -0,0 +1,308 @@
+package animaterm
+
+import (
+	"strings"
+	"sync"
+)
+
+// TableColumn describes a column of a table. Width may be Auto() to fit the
+// widest cell, Fixed, Percent of the table width or Fill.
+type TableColumn struct {
+	Title string
+	Width Size
+	Align Alignment
+}
+
+// TableStyle configures the look of a table. Without Border the columns are
+// separated by a single space. With Zebra every second row uses ZebraStyle.
+// Start from DefaultTableStyle to only change what is needed.
+type TableStyle struct {
+	Border        BorderStyle
+	HeaderStyle   Style
+	RowStyle      Style
+	ZebraStyle    Style
+	SelectedStyle Style
+	Zebra         bool
+}
+
+// DefaultTableStyle draws a single border, a bold header and reverses the
+// selected row
+var DefaultTableStyle = TableStyle{
+	Border:        BorderSingle,
+	HeaderStyle:   Style{Fg: DEFAULTCOLOR, Bg: DEFAULTCOLOR, Bold: true},
+	RowStyle:      DefaultStyle,
+	ZebraStyle:    Style{Fg: DEFAULTCOLOR, Bg: LIGHTGREY},
+	SelectedStyle: Style{Fg: DEFAULTCOLOR, Bg: DEFAULTCOLOR, Reverse: true},
+}
+
+// Table implements ITable
+type Table struct {
+	ui       IUserInterface
+	bounds   Rect
+	columns  []TableColumn
+	rows     [][]string
+	style    TableStyle
+	selected int
+	offset   int
+	mutex    sync.Mutex
+}
+
+// CreateTable creates a table at pos spanning percentWidth and percentHeight
+// of the frame. When there are more rows than fit into the table it scrolls
+// and shows a scrollbar in its rightmost column.
+func CreateTable(ui IUserInterface, pos IRelativePosition, percentWidth int, percentHeight int, columns []TableColumn, style TableStyle) ITable {
+	table := &Table{
+		ui:       ui,
+		bounds:   percentRect(ui, pos, percentWidth, percentHeight),
+		columns:  columns,
+		style:    style,
+		selected: -1,
+	}
+	table.Redraw()
+	return table
+}
+
+// SetRows see ITable
+func (t *Table) SetRows(rows [][]string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.rows = rows
+	if t.selected >= len(rows) {
+		t.selected = -1
+	}
+	t.offset = clampOffset(t.offset, len(t.rows), t.visibleRows())
+	t.draw()
+}
+
+// AddRow see ITable
+func (t *Table) AddRow(cells ...string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.rows = append(t.rows, cells)
+	t.offset = clampOffset(t.offset, len(t.rows), t.visibleRows())
+	t.draw()
+}
+
+// Select see ITable
+func (t *Table) Select(row int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if row < 0 || row >= len(t.rows) {
+		row = -1
+	}
+	t.selected = row
+	t.offset = revealOffset(row, t.offset, t.visibleRows())
+	t.draw()
+}
+
+// MoveSelection see IScrollable
+func (t *Table) MoveSelection(delta int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.selected = moveSelection(t.selected, delta, len(t.rows))
+	t.offset = revealOffset(t.selected, t.offset, t.visibleRows())
+	t.draw()
+}
+
+// ScrollTo see IScrollable
+func (t *Table) ScrollTo(row int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.offset = clampOffset(row, len(t.rows), t.visibleRows())
+	t.draw()
+}
+
+// ScrollBy see IScrollable
+func (t *Table) ScrollBy(rows int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.offset = clampOffset(t.offset+rows, len(t.rows), t.visibleRows())
+	t.draw()
+}
+
+// Offset see IScrollable
+func (t *Table) Offset() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.offset
+}
+
+// Selected see ITable
+func (t *Table) Selected() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.selected
+}
+
+// SetBounds see ILayoutNode
+func (t *Table) SetBounds(bounds Rect) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.bounds = bounds
+	t.offset = clampOffset(t.offset, len(t.rows), t.visibleRows())
+	t.draw()
+}
+
+// Redraw see ITable
+func (t *Table) Redraw() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.draw()
+}
+
+func (t *Table) bordered() bool {
+	return t.style.Border.Top != ""
+}
+
+func (t *Table) hasHeader() bool {
+	for _, column := range t.columns {
+		if column.Title != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// chrome returns the number of lines above and below the rows
+func (t *Table) chrome() (int, int) {
+	top, bottom := 0, 0
+	if t.bordered() {
+		top, bottom = 1, 1
+	}
+	if t.hasHeader() {
+		top++
+		if t.bordered() {
+			top++
+		}
+	}
+	return top, bottom
+}
+
+// visibleRows returns the number of rows that fit into the bounds
+func (t *Table) visibleRows() int {
+	top, bottom := t.chrome()
+	return max(0, t.bounds.Height-top-bottom)
+}
+
+// scrolled reports whether the rows do not fit and a scrollbar is needed
+func (t *Table) scrolled() bool {
+	return len(t.rows) > t.visibleRows()
+}
+
+// columnWidths resolves the width of every column. Auto columns are as wide
+// as their widest cell, within their min and max.
+func (t *Table) columnWidths() []int {
+	available := t.bounds.Width - max(len(t.columns)-1, 0)
+	if t.bordered() {
+		available -= 2
+	}
+	if t.scrolled() {
+		available--
+	}
+	children := make([]layoutChild, len(t.columns))
+	for i, column := range t.columns {
+		size := column.Width
+		if size.Kind == SizeAuto {
+			content := displayWidth(column.Title)
+			for _, row := range t.rows {
+				if i < len(row) {
+					content = max(content, displayWidth(row[i]))
+				}
+			}
+			size.Kind, size.Value = SizeFixed, content
+		}
+		children[i] = layoutChild{size: size}
+	}
+	return resolveSizes(children, max(available, 0))
+}
+
+// rule returns a horizontal border line joining the column separators
+func (t *Table) rule(widths []int, left string, line string, join string, right string) string {
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		parts[i] = strings.Repeat(line, width)
+	}
+	return left + strings.Join(parts, join) + right
+}
+
+// line renders the cells of a row into a single line. The cells and the
+// separators between them are drawn on top of style, cells are cut to their
+// visible width.
+func (t *Table) line(widths []int, cells []string, style Style) string {
+	separator, edge, right := " ", "", ""
+	if t.bordered() {
+		separator, edge, right = t.style.Border.InnerVertical, t.style.Border.Left, t.style.Border.Right
+	}
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = strings.ReplaceAll(cells[i], "\n", " ")
+		}
+		parts[i] = renderOver(style, alignLine(ellipsis(cell, width), width, t.columns[i].Align, true))
+	}
+	return edge + strings.Join(parts, style.Render(separator)) + right
+}
+
+// rowStyle returns the style of the i-th data row
+func (t *Table) rowStyle(i int) Style {
+	switch {
+	case i == t.selected:
+		return t.style.SelectedStyle
+	case t.style.Zebra && i%2 == 1:
+		return t.style.ZebraStyle
+	default:
+		return t.style.RowStyle
+	}
+}
+
+// lines renders the table with the visible window of rows
+func (t *Table) lines() []string {
+	widths := t.columnWidths()
+	border := t.style.Border
+	lines := []string{}
+	if t.bordered() {
+		lines = append(lines, t.rule(widths, border.TopLeft, border.Top, border.TopJoin, border.TopRight))
+	}
+	if t.hasHeader() {
+		titles := make([]string, len(t.columns))
+		for i, column := range t.columns {
+			titles[i] = column.Title
+		}
+		lines = append(lines, t.line(widths, titles, t.style.HeaderStyle))
+		if t.bordered() {
+			lines = append(lines, t.rule(widths, border.LeftJoin, border.InnerHorizontal, border.Cross, border.RightJoin))
+		}
+	}
+	end := min(t.offset+t.visibleRows(), len(t.rows))
+	for i := t.offset; i < end; i++ {
+		lines = append(lines, t.line(widths, t.rows[i], t.rowStyle(i)))
+	}
+	if t.bordered() {
+		lines = append(lines, t.rule(widths, border.BottomLeft, border.Bottom, border.BottomJoin, border.BottomRight))
+	}
+	return lines
+}
+
+// draw renders the table and blanks the rest of its bounds. The caller must
+// hold the mutex.
+func (t *Table) draw() {
+	lines := t.lines()
+	blank := strings.Repeat(" ", t.bounds.Width)
+	for row := 0; row < t.bounds.Height; row++ {
+		y := t.bounds.Y + row
+		if row < len(lines) {
+			x := t.bounds.X + t.ui.DrawClipped(t.bounds, t.bounds.X, y, lines[row], ALREADYCOLORED)
+			t.ui.DrawClipped(t.bounds, x, y, blank, BLANK)
+		} else {
+			t.ui.DrawClipped(t.bounds, t.bounds.X, y, blank, BLANK)
+		}
+	}
+	if t.scrolled() {
+		top, _ := t.chrome()
+		x := t.bounds.X + t.bounds.Width - 1
+		for i, cell := range scrollbar(t.visibleRows(), len(t.rows), t.offset) {
+			t.ui.DrawClipped(t.bounds, x, t.bounds.Y+top+i, cell, ALREADYCOLORED)
+		}
+	}
+}
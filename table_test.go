@@ -0,0 +1,246 @@
+package animaterm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTableBordered(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	style := TableStyle{Border: BorderSingle, HeaderStyle: DefaultStyle, RowStyle: DefaultStyle}
+	columns := []TableColumn{
+		{Title: "Name", Width: Auto()},
+		{Title: "Size", Width: Fixed(6), Align: AlignRight},
+	}
+	table := CreateTable(ui, CreatePos(0, 0), 50, 25, columns, style)
+	table.SetRows([][]string{{"a.txt", "12"}, {"very-long-name.go", "3"}})
+
+	// the auto column fits the longest name, the rest of the bounds stays blank
+	expected := []string{
+		"┌─────────────────┬──────┐              ",
+		"│Name             │  Size│              ",
+		"├─────────────────┼──────┤              ",
+		"│a.txt            │    12│              ",
+		"│very-long-name.go│     3│              ",
+		"└─────────────────┴──────┘              ",
+	}
+	for i, line := range expected {
+		if got := row(ui, i, 0, 40); got != line {
+			t.Errorf("row %d = %q, want %q", i, got, line)
+		}
+	}
+}
+
+func TestTableColumnWidths(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	tests := []struct {
+		name    string
+		columns []TableColumn
+		rows    [][]string
+		want    []int
+	}{
+		{
+			name:    "auto fits content",
+			columns: []TableColumn{{Title: "ID", Width: Auto()}, {Title: "Name", Width: Fill(1)}},
+			rows:    [][]string{{"1234", "x"}},
+			want:    []int{4, 35},
+		},
+		{
+			name:    "auto respects max",
+			columns: []TableColumn{{Width: Auto().WithMax(3)}, {Width: Fill(1)}},
+			rows:    [][]string{{"123456", "x"}},
+			want:    []int{3, 36},
+		},
+		{
+			name:    "percent",
+			columns: []TableColumn{{Width: Percent(50)}, {Width: Percent(50)}},
+			want:    []int{19, 19},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := CreateTable(ui, CreatePos(0, 0), 50, 25, tt.columns, TableStyle{}).(*Table)
+			table.SetRows(tt.rows)
+			got := table.columnWidths()
+			if len(got) != len(tt.want) {
+				t.Fatalf("columnWidths() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("columnWidths() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestTableTruncationAndHeight(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	columns := []TableColumn{{Title: "Col", Width: Fixed(5)}, {Width: Fixed(3), Align: AlignCenter}}
+	table := CreateTable(ui, CreatePos(0, 50), 50, 10, columns, TableStyle{HeaderStyle: DefaultStyle, RowStyle: DefaultStyle})
+	for i := 0; i < 10; i++ {
+		table.AddRow("truncated", "x")
+	}
+	// borderless tables separate columns with a space
+	if got := row(ui, 12, 0, 9); got != "Col      " {
+		t.Errorf("header = %q", got)
+	}
+	if got := row(ui, 13, 0, 9); got != "trun…  x " {
+		t.Errorf("row = %q", got)
+	}
+	// 10% of 24 rows leaves room for the header and one row
+	if got := row(ui, 14, 0, 9); got != strings.Repeat(" ", 9) {
+		t.Errorf("row beyond the table = %q", got)
+	}
+}
+
+func TestTableSelection(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	table := CreateTable(ui, CreatePos(0, 0), 50, 50, []TableColumn{{Width: Fill(1)}}, DefaultTableStyle)
+	table.SetRows([][]string{{"a"}, {"b"}})
+
+	table.Select(1)
+	if table.Selected() != 1 {
+		t.Errorf("Selected() = %d, want 1", table.Selected())
+	}
+	if !strings.Contains(ui.pixels[2][1], "\033[7") {
+		t.Errorf("selected row is not reversed: %q", ui.pixels[2][1])
+	}
+	table.Select(5)
+	if table.Selected() != -1 {
+		t.Errorf("Select out of range should clear the selection, got %d", table.Selected())
+	}
+	table.SetRows(nil)
+	if table.Selected() != -1 {
+		t.Errorf("Selected() after SetRows(nil) = %d", table.Selected())
+	}
+}
+
+func TestTableStyledCells(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	zebra := Style{Fg: DEFAULTCOLOR, Bg: 4}
+	style := TableStyle{HeaderStyle: DefaultStyle, RowStyle: DefaultStyle, ZebraStyle: zebra, Zebra: true}
+	columns := []TableColumn{{Width: Fixed(4)}, {Width: Fixed(2)}}
+	table := CreateTable(ui, CreatePos(0, 0), 50, 50, columns, style)
+	table.SetRows([][]string{{"a", "b"}, {Color("redder", RED), "c"}})
+
+	// escape sequences take no room, the cell is cut after three visible cells
+	if got := stripANSI(row(ui, 1, 0, 7)); got != "red… c " {
+		t.Errorf("styled row = %q", got)
+	}
+	if got := ui.styleAt(0, 1); got.Fg != RED || got.Bg != 4 {
+		t.Errorf("styled cell = %+v, want red on the zebra background", got)
+	}
+	// padding after the reset of the cell and the separator keep the zebra background
+	for x := 3; x < 6; x++ {
+		if got := ui.styleAt(x, 1).Bg; got != 4 {
+			t.Errorf("background at %d = %d, want the zebra background", x, got)
+		}
+	}
+}
+
+func TestTableAddRowClampsOffset(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	table := CreateTable(ui, CreatePos(0, 0), 50, 25, []TableColumn{{Width: Fill(1)}}, TableStyle{}).(*Table)
+	table.offset = 100
+	table.AddRow("a")
+	if table.Offset() != 0 {
+		t.Errorf("Offset() after AddRow = %d, want 0", table.Offset())
+	}
+}
+
+func TestTableScrolling(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	style := TableStyle{Border: BorderSingle, HeaderStyle: DefaultStyle, RowStyle: DefaultStyle, SelectedStyle: DefaultStyle}
+	// 25% of 24 rows leaves 2 rows between header and bottom border
+	table := CreateTable(ui, CreatePos(0, 0), 25, 25, []TableColumn{{Title: "N", Width: Fill(1)}}, style)
+	for i := 0; i < 10; i++ {
+		table.AddRow(fmt.Sprint(i))
+	}
+
+	table.ScrollBy(3)
+	if got := row(ui, 3, 0, 20); got != "│3                │█" {
+		t.Errorf("first visible row = %q", got)
+	}
+	table.Select(9)
+	if table.Offset() != 8 {
+		t.Errorf("Offset() after Select(9) = %d, want 8", table.Offset())
+	}
+	if got := row(ui, 4, 0, 20); got != "│9                │█" {
+		t.Errorf("last visible row = %q", got)
+	}
+	table.SetRows([][]string{{"x"}})
+	if table.Offset() != 0 {
+		t.Errorf("Offset() after SetRows = %d, want 0", table.Offset())
+	}
+	if got := row(ui, 3, 0, 20); got != "│x                 │" {
+		t.Errorf("row without scrollbar = %q", got)
+	}
+}
+
+func TestDrawTableScrolled(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	table := [][]string{}
+	for i := 0; i < 10; i++ {
+		table = append(table, []string{fmt.Sprintf("r%d", i), "x"})
+	}
+	positions, colors := []int{0, 10}, []int{ALREADYCOLORED, ALREADYCOLORED}
+
+	tests := []struct {
+		name   string
+		offset int
+		want   []string
+		bar    []string
+	}{
+		{name: "top", offset: 0, want: []string{"r0", "r1", "r2"}, bar: []string{ScrollbarThumb, ScrollbarTrack, ScrollbarTrack}},
+		{name: "middle", offset: 4, want: []string{"r4", "r5", "r6"}},
+		{name: "past the end", offset: 100, want: []string{"r7", "r8", "r9"}, bar: []string{ScrollbarTrack, ScrollbarTrack, ScrollbarThumb}},
+		{name: "before the start", offset: -5, want: []string{"r0", "r1", "r2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = ui.initPixels(24, 80)
+			ui.DrawTableScrolled(CreatePos(0, 0), table, positions, colors, tt.offset, 3)
+			for y, want := range tt.want {
+				if got := stripANSI(row(ui, y, 0, 2)); got != want {
+					t.Errorf("row %d = %q, want %q", y, got, want)
+				}
+			}
+			// rows below the view stay untouched
+			if got := stripANSI(row(ui, 3, 0, 2)); got != "  " {
+				t.Errorf("row below the view = %q", got)
+			}
+			for y, want := range tt.bar {
+				if got := row(ui, y, 79, 1); got != want {
+					t.Errorf("scrollbar row %d = %q, want %q", y, got, want)
+				}
+			}
+		})
+	}
+
+	// no visible rows draw nothing
+	_ = ui.initPixels(24, 80)
+	for _, visibleRows := range []int{0, -1} {
+		ui.DrawTableScrolled(CreatePos(0, 0), table, positions, colors, 5, visibleRows)
+	}
+	if got := stripANSI(row(ui, 0, 0, 80)); strings.TrimSpace(got) != "" {
+		t.Errorf("drawn without visible rows: %q", got)
+	}
+
+	// without overflow there is no scrollbar
+	_ = ui.initPixels(24, 80)
+	ui.DrawTableScrolled(CreatePos(0, 0), table[:2], positions, colors, 0, 3)
+	if got := row(ui, 0, 79, 1); got != " " {
+		t.Errorf("scrollbar drawn without overflow: %q", got)
+	}
+}
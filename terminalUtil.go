@@ -10,18 +10,20 @@ import (
 )
 
 var pl = fmt.Println
-var p = fmt.Print
 var pf = fmt.Printf
 
 // Animation defines the parameters for animated operations.
 // Duration is in milliseconds, Direction controls expansion/movement direction,
 // and gradient flags enable color transitions during animation.
+// A Gradient replaces the color and the gradient flags; it is spread across
+// the element and moves with its Speed while the animation runs.
 type Animation struct {
 	AnimationType AnimationType
 	Duration      int64
 	Direction     Direction
 	GradientV     bool
 	GradientH     bool
+	Gradient      *Gradient
 }
 
 // Direction ...
@@ -108,6 +110,7 @@ const (
 	RANDOM                      int = 503
 	BLANK                       int = 504
 	ALREADYCOLORED              int = 505
+	MARKUP                      int = 506
 )
 
 func getControlSequence(sequence int) string {
@@ -133,13 +136,47 @@ func getControlSequence(sequence int) string {
 
 // Color applies ANSI color codes to text for terminal display.
 // Supports 256-color mode, special effects, and handles blank/pre-colored text.
+// With MARKUP the style tags inside str are converted, see Markup.
 func Color(str string, color int) string {
 	if color == BLANK || color == ALREADYCOLORED {
 		return str
 	}
+	if color == MARKUP {
+		return Markup(str)
+	}
 	return fmt.Sprintf("%s%s%s", getControlSequence(color), str, getControlSequence(RESET))
 }
 
+// gradientStep returns the palette color at factor (0-1) of the gradient
+// starting at color, as used by gradient animations. The gradient is
+// interpolated in OKLab, so consecutive steps change smoothly instead of
+// jumping across unrelated hues. Colors outside the palette are returned
+// unchanged.
+func gradientStep(color int, factor float32) int {
+	if color < 0 || color > 255 {
+		return color
+	}
+	return PaletteColor(color).Lerp(PaletteColor(gradientEnd(color)), float64(factor)).Palette()
+}
+
+// gradientEnd returns the color a gradient starting at color runs to: the
+// far end of its row in the color cube or of the grey ramp
+func gradientEnd(color int) int {
+	if color < 16 {
+		color = PaletteColor(color).Palette()
+	}
+	if color >= 232 {
+		if color < 244 {
+			return 255
+		}
+		return 232
+	}
+	if blue := (color - 16) % 6; blue < 5 {
+		return color - blue + 5
+	}
+	return color - 5
+}
+
 // getTerminalSize returns the terminal width and height using cross-platform term package
 func getTerminalSize() (int, int, error) {
 	width, height, err := term.GetSize(int(os.Stdout.Fd()))
@@ -0,0 +1,172 @@
+package animaterm
+
+import (
+	"strings"
+)
+
+// WrapMode selects how DrawText handles lines wider than the maximum width
+type WrapMode int
+
+// WrapWord ...
+const (
+	// WrapWord breaks lines between words, words wider than a line are split
+	WrapWord WrapMode = iota
+	// WrapHard breaks lines at exactly the maximum width
+	WrapHard
+	// WrapTruncate cuts lines at the maximum width
+	WrapTruncate
+	// WrapEllipsis cuts lines at the maximum width and marks the cut with "…"
+	WrapEllipsis
+)
+
+// Alignment selects how lines narrower than the maximum width are aligned
+type Alignment int
+
+// AlignLeft ...
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+	// AlignJustify stretches the gaps between words to fill the width; the
+	// last line of each paragraph stays left aligned
+	AlignJustify
+)
+
+// TextOptions configures DrawText and FormatText. MaxWidth is measured in
+// cells, 0 lets DrawText use the space up to the right edge of the frame.
+type TextOptions struct {
+	MaxWidth int
+	Wrap     WrapMode
+	Align    Alignment
+}
+
+// DrawText renders text as a block of lines no wider than opts.MaxWidth,
+// wrapped and aligned according to opts. The block is aligned to pos
+// according to its anchor. Styles of ALREADYCOLORED and MARKUP text carry
+// over to the wrapped lines. Returns the number of lines drawn.
+func (ui *UserInterface) DrawText(pos IRelativePosition, text string, color int, opts TextOptions) int {
+	x, y := ui.PositionToAbsolute(pos)
+	if opts.MaxWidth <= 0 {
+		opts.MaxWidth = ui.PercentToAbsoluteXPostion(100) - x
+	}
+	text, color = resolveMarkup(text, color)
+	lines := FormatText(text, opts)
+	if color == ALREADYCOLORED {
+		lines = carryStyles(lines)
+	}
+	shiftX, shiftY := pos.GetAnchor().shift(opts.MaxWidth, len(lines))
+	screen := Rect{Width: ui.width, Height: ui.height}
+	for k, line := range lines {
+		ui.DrawClipped(screen, x+shiftX, y+shiftY+k, line, color)
+	}
+	return len(lines)
+}
+
+// FormatText breaks text into lines of exactly opts.MaxWidth cells, wrapped
+// and aligned according to opts. Newlines in text always start a new line.
+// Escape sequences take no room and stay in the line they appear in; style
+// tags are not interpreted, resolve them with Markup first.
+func FormatText(text string, opts TextOptions) []string {
+	width := opts.MaxWidth
+	if width <= 0 {
+		return getLines(text, false)
+	}
+	lines := []string{}
+	for _, paragraph := range getLines(text, false) {
+		var wrapped []string
+		switch opts.Wrap {
+		case WrapHard:
+			wrapped = hardWrap(paragraph, width)
+		case WrapTruncate:
+			wrapped = []string{truncateCells(paragraph, width)}
+		case WrapEllipsis:
+			wrapped = []string{ellipsis(paragraph, width)}
+		default:
+			wrapped = wordWrap(paragraph, width)
+		}
+		for i, line := range wrapped {
+			lines = append(lines, alignLine(line, width, opts.Align, i == len(wrapped)-1))
+		}
+	}
+	return lines
+}
+
+// ellipsis truncates s to width cells, replacing the last visible cell with
+// "…" if anything was cut off
+func ellipsis(s string, width int) string {
+	if displayWidth(s) <= width || width <= 0 {
+		return truncateCells(s, width)
+	}
+	return truncateCells(s, width-1) + "…"
+}
+
+// hardWrap splits s into chunks of width cells
+func hardWrap(s string, width int) []string {
+	lines := []string{}
+	for displayWidth(s) > width {
+		chunk := truncateCells(s, width)
+		if chunk == "" {
+			// a wide cluster does not fit into a single cell
+			chunk = graphemes(s)[0]
+		}
+		lines = append(lines, chunk)
+		s = s[len(chunk):]
+	}
+	return append(lines, s)
+}
+
+// wordWrap breaks s between words so no line exceeds width cells. Runs of
+// whitespace collapse into single spaces.
+func wordWrap(s string, width int) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(s) {
+		switch {
+		case line == "" && displayWidth(word) <= width:
+			line = word
+		case line != "" && displayWidth(line)+1+displayWidth(word) <= width:
+			line += " " + word
+		default:
+			if line != "" {
+				lines = append(lines, line)
+			}
+			chunks := hardWrap(word, width)
+			lines = append(lines, chunks[:len(chunks)-1]...)
+			line = chunks[len(chunks)-1]
+		}
+	}
+	return append(lines, line)
+}
+
+// alignLine pads line to width cells according to align
+func alignLine(line string, width int, align Alignment, last bool) string {
+	gap := width - displayWidth(line)
+	if gap <= 0 {
+		return line
+	}
+	switch align {
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + line + strings.Repeat(" ", gap-gap/2)
+	case AlignRight:
+		return strings.Repeat(" ", gap) + line
+	case AlignJustify:
+		words := strings.Fields(line)
+		if last || len(words) < 2 {
+			break
+		}
+		// distribute all spaces of the line over its gaps, leftmost first
+		spaces := width - displayWidth(strings.Join(words, ""))
+		var sb strings.Builder
+		for i, word := range words {
+			sb.WriteString(word)
+			if i < len(words)-1 {
+				gaps := len(words) - 1 - i
+				n := (spaces + gaps - 1) / gaps
+				sb.WriteString(strings.Repeat(" ", n))
+				spaces -= n
+			}
+		}
+		return sb.String()
+	}
+	return line + strings.Repeat(" ", gap)
+}
@@ -0,0 +1,88 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatText(t *testing.T) {
+	text := "the quick brown fox jumps over the lazy dog"
+	tests := []struct {
+		name     string
+		text     string
+		opts     TextOptions
+		expected []string
+	}{
+		{"Word wrap", text, TextOptions{MaxWidth: 15}, []string{
+			"the quick brown",
+			"fox jumps over ",
+			"the lazy dog   ",
+		}},
+		{"Hard wrap", text, TextOptions{MaxWidth: 15, Wrap: WrapHard}, []string{
+			"the quick brown",
+			" fox jumps over",
+			" the lazy dog  ",
+		}},
+		{"Long word", "a supercalifragilistic word", TextOptions{MaxWidth: 8}, []string{
+			"a       ",
+			"supercal",
+			"ifragili",
+			"stic    ",
+			"word    ",
+		}},
+		{"Truncate", text, TextOptions{MaxWidth: 9, Wrap: WrapTruncate}, []string{"the quick"}},
+		{"Ellipsis", text, TextOptions{MaxWidth: 9, Wrap: WrapEllipsis}, []string{"the quic…"}},
+		{"Ellipsis fits", "short", TextOptions{MaxWidth: 9, Wrap: WrapEllipsis}, []string{"short    "}},
+		{"Center", "ab\nabc", TextOptions{MaxWidth: 6, Align: AlignCenter}, []string{"  ab  ", " abc  "}},
+		{"Right", "ab", TextOptions{MaxWidth: 6, Align: AlignRight}, []string{"    ab"}},
+		{"Justify", text, TextOptions{MaxWidth: 16, Align: AlignJustify}, []string{
+			"the  quick brown",
+			"fox  jumps  over",
+			"the lazy dog    ",
+		}},
+		{"Escapes take no room", "\033[31mab cd\033[0m", TextOptions{MaxWidth: 3, Align: AlignRight}, []string{" \033[31mab", " cd\033[0m"}},
+		{"Escapes in a hard wrap", "\033[1mabcd", TextOptions{MaxWidth: 2, Wrap: WrapHard}, []string{"\033[1mab", "cd"}},
+		{"Escapes before the ellipsis", "\033[31mabcdef", TextOptions{MaxWidth: 4, Wrap: WrapEllipsis}, []string{"\033[31mabc…"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatText(tt.text, tt.opts)
+			if strings.Join(result, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("FormatText() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.SetBorder(0)
+
+	lines := ui.DrawText(CreatePos(0, 0), "hello wide world", ALREADYCOLORED, TextOptions{MaxWidth: 10, Align: AlignRight})
+	if lines != 2 {
+		t.Fatalf("DrawText() = %d lines, want 2", lines)
+	}
+	if got := row(ui, 0, 0, 10); got != "hello wide" {
+		t.Errorf("first line = %q", got)
+	}
+	if got := row(ui, 1, 0, 10); got != "     world" {
+		t.Errorf("second line = %q", got)
+	}
+}
+
+func TestDrawTextStyles(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	_ = ui.SetBorder(0)
+
+	ui.DrawText(CreatePos(0, 0), "[red]hello world[/] x", MARKUP, TextOptions{MaxWidth: 7})
+	if got := stripANSI(row(ui, 0, 0, 7) + "|" + row(ui, 1, 0, 7)); got != "hello  |world x" {
+		t.Errorf("wrapped markup = %q", got)
+	}
+	for _, cell := range []struct{ x, y, fg int }{{0, 0, RED}, {0, 1, RED}, {4, 1, RED}, {6, 1, DEFAULTCOLOR}} {
+		if got := ui.styleAt(cell.x, cell.y).Fg; got != cell.fg {
+			t.Errorf("color at %d,%d = %d, want %d", cell.x, cell.y, got, cell.fg)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package animaterm
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TextEffectKind selects how a text effect reveals its text
+type TextEffectKind int
+
+// EffectTypewriter ...
+const (
+	// EffectTypewriter types the text character by character
+	EffectTypewriter TextEffectKind = iota
+	// EffectWipeRight reveals the text column by column from left to right
+	EffectWipeRight
+	// EffectWipeLeft reveals the text column by column from right to left
+	EffectWipeLeft
+	// EffectWipeCenter reveals the text from its center column outwards
+	EffectWipeCenter
+	// EffectDissolve reveals the characters in random order
+	EffectDissolve
+	// EffectDecrypt shows scrambled characters that settle in random order
+	EffectDecrypt
+)
+
+// TextEffect configures a text effect. The typewriter types CPS characters
+// per second (30 if unset) with Cursor in front of the next character, all
+// other effects take Duration (one second if unset). Decrypt scrambles with
+// the characters of Charset. Seed makes the random order reproducible, 0
+// picks a random seed.
+type TextEffect struct {
+	Kind     TextEffectKind
+	Duration time.Duration
+	CPS      float64
+	Cursor   string
+	Charset  string
+	Seed     int64
+}
+
+const defaultCharset = "!#$%&*+<=>?@0123456789ABCDEFabcdef"
+
+// revealCell is a cluster of the text together with the time it is revealed at
+type revealCell struct {
+	tweenCell
+	at       time.Duration
+	revealed bool
+}
+
+// TextReveal implements ITextEffect
+type TextReveal struct {
+	ui     IUserInterface
+	screen Rect
+	effect TextEffect
+	cells  []revealCell
+	start  time.Time
+	random *rand.Rand
+	done   bool
+	mutex  sync.Mutex
+}
+
+// CreateTextEffect reveals text at pos with effect and registers it with the
+// draw loop of ui. Text may span multiple lines and is laid out like
+// DrawElement does, including the anchor of pos. Cells not yet revealed keep
+// what is underneath.
+func CreateTextEffect(ui IUserInterface, pos IRelativePosition, text string, color int, effect TextEffect) ITextEffect {
+	if effect.CPS <= 0 {
+		effect.CPS = 30
+	}
+	if effect.Duration <= 0 {
+		effect.Duration = time.Second
+	}
+	if effect.Charset == "" {
+		effect.Charset = defaultCharset
+	}
+	seed := effect.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	tr := &TextReveal{
+		ui:     ui,
+		effect: effect,
+		start:  time.Now(),
+		random: rand.New(rand.NewSource(seed)),
+	}
+	width, height := ui.Size()
+	tr.screen = Rect{Width: width, Height: height}
+	for _, cell := range layoutCells(ui, pos, text, color) {
+		// wrap around the edges like DrawElement
+		if width > 0 && height > 0 {
+			cell.x, cell.y = cell.x%width, cell.y%height
+		}
+		tr.cells = append(tr.cells, revealCell{tweenCell: cell})
+	}
+	tr.schedule()
+	tr.Tick(tr.start)
+	ui.AddTicker(tr)
+	return tr
+}
+
+// schedule sets the time every cell is revealed at
+func (tr *TextReveal) schedule() {
+	if len(tr.cells) == 0 {
+		return
+	}
+	left, right := tr.cells[0].x, tr.cells[0].x
+	for _, cell := range tr.cells {
+		left = min(left, cell.x)
+		right = max(right, cell.x+clusterWidth(cell.text)-1)
+	}
+	duration := float64(tr.effect.Duration)
+	order := tr.random.Perm(len(tr.cells))
+	for i := range tr.cells {
+		cell := &tr.cells[i]
+		column := float64(cell.x - left)
+		width := float64(right - left + 1)
+		switch tr.effect.Kind {
+		case EffectTypewriter:
+			cell.at = time.Duration(float64(i) / tr.effect.CPS * float64(time.Second))
+		case EffectWipeRight:
+			cell.at = time.Duration(duration * column / width)
+		case EffectWipeLeft:
+			cell.at = time.Duration(duration * (width - 1 - column) / width)
+		case EffectWipeCenter:
+			center := (width - 1) / 2
+			cell.at = time.Duration(duration * max(column-center, center-column) / max(center, 1))
+		case EffectDissolve:
+			cell.at = time.Duration(duration * float64(order[i]) / float64(len(tr.cells)))
+		case EffectDecrypt:
+			// everything scrambles for a quarter of the time before settling
+			cell.at = time.Duration(duration * (0.25 + 0.75*float64(order[i]+1)/float64(len(tr.cells))))
+		}
+	}
+}
+
+// Tick see ITicker
+func (tr *TextReveal) Tick(now time.Time) bool {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	if tr.done {
+		return false
+	}
+	elapsed := now.Sub(tr.start)
+	pending := 0
+	for i := range tr.cells {
+		cell := &tr.cells[i]
+		if cell.revealed {
+			continue
+		}
+		if cell.at <= elapsed {
+			tr.draw(cell.tweenCell, cell.text)
+			cell.revealed = true
+			continue
+		}
+		pending++
+		if tr.effect.Kind == EffectDecrypt && strings.TrimSpace(cell.text) != "" {
+			tr.draw(cell.tweenCell, tr.scramble(clusterWidth(cell.text)))
+		}
+	}
+	tr.drawCursor(pending)
+	tr.done = pending == 0
+	return !tr.done
+}
+
+// Done see ITextEffect
+func (tr *TextReveal) Done() bool {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	return tr.done
+}
+
+// Skip see ITextEffect
+func (tr *TextReveal) Skip() {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	for i := range tr.cells {
+		if !tr.cells[i].revealed {
+			tr.draw(tr.cells[i].tweenCell, tr.cells[i].text)
+			tr.cells[i].revealed = true
+		}
+	}
+	tr.done = true
+}
+
+// drawCursor draws the typewriter cursor onto the next pending cell. Once
+// that cell is revealed it overwrites the cursor. The caller must hold the
+// mutex.
+func (tr *TextReveal) drawCursor(pending int) {
+	if tr.effect.Kind != EffectTypewriter || tr.effect.Cursor == "" || pending == 0 {
+		return
+	}
+	tr.draw(tr.cells[len(tr.cells)-pending].tweenCell, tr.effect.Cursor)
+}
+
+// scramble returns width random characters of the charset
+func (tr *TextReveal) scramble(width int) string {
+	charset := []rune(tr.effect.Charset)
+	var sb strings.Builder
+	for i := 0; i < width; i++ {
+		sb.WriteRune(charset[tr.random.Intn(len(charset))])
+	}
+	return sb.String()
+}
+
+// draw renders text at the cell in the cell's style
+func (tr *TextReveal) draw(cell tweenCell, text string) {
+	tr.ui.DrawClipped(tr.screen, cell.x, cell.y, cell.to.Render(text), ALREADYCOLORED)
+}
@@ -0,0 +1,93 @@
+package animaterm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypewriter(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	effect := CreateTextEffect(ui, CreatePos(0, 0), "ab\ncd", ALREADYCOLORED, TextEffect{CPS: 10, Cursor: "_"}).(*TextReveal)
+	start := effect.start
+
+	if got := row(ui, 0, 0, 2); got != "a_" {
+		t.Errorf("after the start = %q, want %q", got, "a_")
+	}
+	// one character every 100ms, the cursor moves onto the next line
+	effect.Tick(start.Add(150 * time.Millisecond))
+	if got := row(ui, 0, 0, 2) + row(ui, 1, 0, 2); got != "ab_ " {
+		t.Errorf("after 150ms = %q, want %q", got, "ab_ ")
+	}
+	if !effect.Tick(start.Add(250 * time.Millisecond)) {
+		t.Error("Tick() returned false before the text was typed")
+	}
+	if effect.Tick(start.Add(time.Second)) {
+		t.Error("Tick() returned true after the text was typed")
+	}
+	if got := row(ui, 0, 0, 2) + row(ui, 1, 0, 2); got != "abcd" || !effect.Done() {
+		t.Errorf("at the end = %q, done %v", got, effect.Done())
+	}
+}
+
+func TestTextEffectStaysOnScreen(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	hidden := ui.pixels[0][80]
+	effect := CreateTextEffect(ui, CreateMixedPos(Coord{Percent: 100, Cells: -1}, Coord{}), "ab", ALREADYCOLORED, TextEffect{Kind: EffectWipeRight}).(*TextReveal)
+	effect.Skip()
+	if got := ui.pixels[0][80]; got != hidden {
+		t.Errorf("effect drew into the hidden column: %q", got)
+	}
+	// like DrawElement the text wraps around the right edge
+	if got := row(ui, 0, 79, 1) + row(ui, 0, 0, 1); got != "ab" {
+		t.Errorf("cells at the edge = %q", got)
+	}
+}
+
+func TestRevealOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		kind TextEffectKind
+		// the cells visible after half of the duration
+		want string
+	}{
+		{name: "wipe right", kind: EffectWipeRight, want: "abc  "},
+		{name: "wipe left", kind: EffectWipeLeft, want: "  cde"},
+		{name: "wipe center", kind: EffectWipeCenter, want: " bcd "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := CreateUI().(*UserInterface)
+			effect := CreateTextEffect(ui, CreatePos(0, 0), "abcde", ALREADYCOLORED, TextEffect{Kind: tt.kind, Duration: time.Second}).(*TextReveal)
+			effect.Tick(effect.start.Add(500 * time.Millisecond))
+			if got := row(ui, 0, 0, 5); got != tt.want {
+				t.Errorf("after half of the duration = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDissolveAndDecrypt(t *testing.T) {
+	for _, kind := range []TextEffectKind{EffectDissolve, EffectDecrypt} {
+		ui := CreateUI().(*UserInterface)
+		effect := CreateTextEffect(ui, CreatePos(0, 0), "hello world", ALREADYCOLORED, TextEffect{Kind: kind, Seed: 7}).(*TextReveal)
+		effect.Tick(effect.start.Add(600 * time.Millisecond))
+		if got := row(ui, 0, 0, 11); got == "hello world" {
+			t.Errorf("kind %d revealed everything after 600ms", kind)
+		}
+		effect.Skip()
+		if got := row(ui, 0, 0, 11); got != "hello world" || !effect.Done() {
+			t.Errorf("kind %d after Skip() = %q", kind, got)
+		}
+		if effect.Tick(time.Now()) {
+			t.Errorf("kind %d ticks after Skip()", kind)
+		}
+	}
+
+	// decrypt fills every pending cell with a scrambled character
+	ui := CreateUI().(*UserInterface)
+	CreateTextEffect(ui, CreatePos(0, 0), "abc", ALREADYCOLORED, TextEffect{Kind: EffectDecrypt, Charset: "#"})
+	if got := row(ui, 0, 0, 3); got != "###" {
+		t.Errorf("decrypt at the start = %q, want %q", got, "###")
+	}
+}
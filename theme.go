@@ -0,0 +1,153 @@
+package animaterm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// Theme maps semantic roles onto styles. The roles are available as markup
+// tags, e.g. "[success]done[/]", and are resolved against the current theme
+// whenever markup is drawn, so switching the theme changes the look of
+// everything drawn afterwards.
+type Theme struct {
+	Name       string
+	Primary    Style
+	Accent     Style
+	Success    Style
+	Warning    Style
+	Error      Style
+	Muted      Style
+	Background Style
+}
+
+// DarkTheme is the default theme, meant for terminals with a dark background
+var DarkTheme = Theme{
+	Name:       "dark",
+	Primary:    Style{Fg: COLORPATTERNSKYLIGHT, Bg: DEFAULTCOLOR, Bold: true},
+	Accent:     Style{Fg: PINK, Bg: DEFAULTCOLOR},
+	Success:    Style{Fg: COLORPATTERNLIME, Bg: DEFAULTCOLOR},
+	Warning:    Style{Fg: ORANGE, Bg: DEFAULTCOLOR},
+	Error:      Style{Fg: RED2, Bg: DEFAULTCOLOR},
+	Muted:      Style{Fg: 244, Bg: DEFAULTCOLOR},
+	Background: Style{Fg: 252, Bg: 235},
+}
+
+// LightTheme is meant for terminals with a light background
+var LightTheme = Theme{
+	Name:       "light",
+	Primary:    Style{Fg: 25, Bg: DEFAULTCOLOR, Bold: true},
+	Accent:     Style{Fg: 127, Bg: DEFAULTCOLOR},
+	Success:    Style{Fg: 28, Bg: DEFAULTCOLOR},
+	Warning:    Style{Fg: 130, Bg: DEFAULTCOLOR},
+	Error:      Style{Fg: RED2, Bg: DEFAULTCOLOR},
+	Muted:      Style{Fg: 245, Bg: DEFAULTCOLOR},
+	Background: Style{Fg: 235, Bg: 255},
+}
+
+var (
+	currentTheme = DarkTheme
+	themeMutex   sync.RWMutex
+)
+
+// SetTheme switches the theme used for role tags
+func SetTheme(theme Theme) {
+	themeMutex.Lock()
+	defer themeMutex.Unlock()
+	currentTheme = theme
+}
+
+// CurrentTheme returns the theme used for role tags
+func CurrentTheme() Theme {
+	themeMutex.RLock()
+	defer themeMutex.RUnlock()
+	return currentTheme
+}
+
+// roles returns the styles of the theme by their lower case role name
+func (t *Theme) roles() map[string]*Style {
+	return map[string]*Style{
+		"primary":    &t.Primary,
+		"accent":     &t.Accent,
+		"success":    &t.Success,
+		"warning":    &t.Warning,
+		"error":      &t.Error,
+		"muted":      &t.Muted,
+		"background": &t.Background,
+	}
+}
+
+// Role returns the style of a role by its name, e.g. "warning"
+func (t Theme) Role(name string) (Style, bool) {
+	style, ok := t.roles()[strings.ToLower(name)]
+	if !ok {
+		return Style{}, false
+	}
+	return *style, true
+}
+
+// ParseTheme reads a theme from JSON. Roles are given as style specs (see
+// ParseStyle), roles that are left out are taken from the theme named by
+// "base", which is "dark" or "light" and defaults to "dark".
+//
+//	{"name": "ci", "base": "light", "primary": "bold blue", "error": "bold white on red"}
+func ParseTheme(data []byte) (Theme, error) {
+	fields := map[string]string{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Theme{}, fmt.Errorf("parsing theme: %w", err)
+	}
+	theme := DarkTheme
+	switch base := strings.ToLower(fields["base"]); base {
+	case "", "dark":
+	case "light":
+		theme = LightTheme
+	default:
+		return Theme{}, fmt.Errorf("unknown base theme %q", fields["base"])
+	}
+	theme.Name = fields["name"]
+	roles := theme.roles()
+	for key, spec := range fields {
+		if key == "name" || key == "base" {
+			continue
+		}
+		role, ok := roles[strings.ToLower(key)]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme role %q", key)
+		}
+		style, err := ParseStyle(spec)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme role %s: %w", key, err)
+		}
+		*role = style
+	}
+	return theme, nil
+}
+
+// LoadTheme parses the JSON theme file name from fsys, see ParseTheme
+func LoadTheme(fsys fs.FS, name string) (Theme, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return Theme{}, err
+	}
+	return ParseTheme(data)
+}
+
+// overlay returns base with the colors and attributes set in top applied
+func overlay(base Style, top Style) Style {
+	if top.Fg != DEFAULTCOLOR {
+		base.Fg = top.Fg
+	}
+	if top.Bg != DEFAULTCOLOR {
+		base.Bg = top.Bg
+	}
+	base.Bold = base.Bold || top.Bold
+	base.Dim = base.Dim || top.Dim
+	base.Italic = base.Italic || top.Italic
+	base.Underline = base.Underline || top.Underline
+	base.Blink = base.Blink || top.Blink
+	base.Reverse = base.Reverse || top.Reverse
+	base.Strike = base.Strike || top.Strike
+	return base
+}
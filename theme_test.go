@@ -0,0 +1,81 @@
+package animaterm
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		check   func(Theme) bool
+		wantErr bool
+	}{
+		{
+			name: "roles override the dark base",
+			json: `{"name": "ci", "primary": "bold blue", "error": "white on red"}`,
+			check: func(th Theme) bool {
+				return th.Name == "ci" && th.Primary.Fg == BLUE && th.Error.Bg == RED && th.Success == DarkTheme.Success
+			},
+		},
+		{
+			name:  "light base",
+			json:  `{"base": "light", "Muted": "dim"}`,
+			check: func(th Theme) bool { return th.Muted.Dim && th.Success == LightTheme.Success },
+		},
+		{name: "unknown base", json: `{"base": "solarized"}`, wantErr: true},
+		{name: "unknown role", json: `{"danger": "red"}`, wantErr: true},
+		{name: "invalid style", json: `{"accent": "sparkly"}`, wantErr: true},
+		{name: "invalid json", json: `{"accent": 1}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := ParseTheme([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !tt.check(theme) {
+				t.Errorf("ParseTheme() = %+v", theme)
+			}
+		})
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	fsys := fstest.MapFS{"themes/team.json": {Data: []byte(`{"name": "team", "accent": "pink"}`)}}
+	theme, err := LoadTheme(fsys, "themes/team.json")
+	if err != nil {
+		t.Fatalf("LoadTheme() error = %v", err)
+	}
+	if theme.Accent.Fg != PINK {
+		t.Errorf("accent = %+v", theme.Accent)
+	}
+	if _, err := LoadTheme(fsys, "missing.json"); err == nil {
+		t.Error("LoadTheme() of a missing file should return an error")
+	}
+}
+
+func TestThemeRolesInMarkup(t *testing.T) {
+	defer SetTheme(CurrentTheme())
+
+	SetTheme(DarkTheme)
+	if got, want := Markup("[success]ok[/]"), DarkTheme.Success.Render("ok"); got != want {
+		t.Errorf("dark success = %q, want %q", got, want)
+	}
+	SetTheme(LightTheme)
+	if got, want := Markup("[success]ok[/]"), LightTheme.Success.Render("ok"); got != want {
+		t.Errorf("light success = %q, want %q", got, want)
+	}
+	// attributes of the role are added to the enclosing style
+	style, err := ParseStyle("underline primary")
+	if err != nil {
+		t.Fatalf("ParseStyle() error = %v", err)
+	}
+	if !style.Underline || !style.Bold || style.Fg != LightTheme.Primary.Fg {
+		t.Errorf("underline primary = %+v", style)
+	}
+	if _, ok := LightTheme.Role("nonsense"); ok {
+		t.Error("Role() found an unknown role")
+	}
+}
@@ -0,0 +1,220 @@
+package animaterm
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// TransitionKind selects how a transition moves from one screen to the next
+type TransitionKind int
+
+// TransitionWipe ...
+const (
+	// TransitionWipe uncovers the next screen with an edge moving in the
+	// direction of the animation
+	TransitionWipe TransitionKind = iota
+	// TransitionSlide pushes the current screen out in the direction of the
+	// animation while the next screen slides in behind it
+	TransitionSlide
+	// TransitionDissolve replaces the cells in random order
+	TransitionDissolve
+	// TransitionFade fades the current screen to black and the next one in
+	TransitionFade
+	// TransitionCurtain splits the current screen in the middle and moves both
+	// halves apart, sideways for Left and Right, up and down for Up and Down
+	TransitionCurtain
+)
+
+// Transition see IUserInterface
+func (ui *UserInterface) Transition(scene func(next IUserInterface), kind TransitionKind, animation Animation) error {
+	if scene == nil {
+		return fmt.Errorf("scene cannot be nil")
+	}
+	if kind < TransitionWipe || kind > TransitionCurtain {
+		return fmt.Errorf("unknown transition %d", kind)
+	}
+	if animation.Duration < 0 {
+		return fmt.Errorf("animation duration cannot be negative")
+	}
+	screen := Rect{Width: ui.width, Height: ui.height}
+	next := ui.offscreen()
+	scene(next)
+	t := newTransition(ui.Snapshot(screen), next.Snapshot(screen), kind, animation.Direction)
+	ui.animate(animation, func(factor float64) {
+		ui.Restore(0, 0, t.frame(factor))
+	})
+	return nil
+}
+
+// offscreen returns a user interface of the same size and borders as ui
+// that is never rendered to the terminal, its output is discarded
+func (ui *UserInterface) offscreen() *UserInterface {
+	ui.frameMutex.RLock()
+	defer ui.frameMutex.RUnlock()
+	next := &UserInterface{
+		absBorderLeft:   ui.absBorderLeft,
+		absBorderRight:  ui.absBorderRight,
+		absBorderTop:    ui.absBorderTop,
+		absBorderBottom: ui.absBorderBottom,
+		msPerFrame:      ui.msPerFrame,
+		output:          io.Discard,
+	}
+	_ = next.initPixels(ui.height, ui.width)
+	return next
+}
+
+// transition composes the frames between two snapshots of the screen
+type transition struct {
+	screens   [2][][]string
+	width     int
+	height    int
+	kind      TransitionKind
+	direction Direction
+	order     []int
+}
+
+// source is the cell of a screen shown at a cell of a frame. Screen 0 is
+// the current and 1 the next screen, dark (0-1) darkens its colors.
+type source struct {
+	screen int
+	x      int
+	y      int
+	dark   float64
+}
+
+func newTransition(from [][]string, to [][]string, kind TransitionKind, direction Direction) *transition {
+	t := &transition{screens: [2][][]string{from, to}, height: len(from), kind: kind, direction: direction}
+	if t.height > 0 {
+		t.width = len(from[0])
+	}
+	if kind == TransitionDissolve {
+		// a fixed seed keeps the pattern the same on every run
+		t.order = rand.New(rand.NewSource(1)).Perm(t.width * t.height)
+	}
+	return t
+}
+
+// frame returns the screen at factor (0-1) of the transition. Wide clusters
+// cut apart by the transition are replaced by blanks.
+func (t *transition) frame(factor float64) [][]string {
+	pixels := make([][]string, t.height)
+	sources := make([]source, t.width)
+	for y := range pixels {
+		pixels[y] = make([]string, t.width)
+		for x := range sources {
+			sources[x] = t.pick(x, y, factor)
+		}
+		for x, s := range sources {
+			pixel := t.screens[s.screen][s.y][s.x]
+			switch {
+			case pixel == continuationCell:
+				if x == 0 || !t.follows(sources[x-1], s) {
+					pixel = " "
+				}
+			case s.x+1 < t.width && t.screens[s.screen][s.y][s.x+1] == continuationCell:
+				if x+1 == t.width || !t.follows(s, sources[x+1]) {
+					pixel = " "
+				}
+			}
+			if s.dark > 0 {
+				pixel = darken(pixel, s.dark)
+			}
+			pixels[y][x] = pixel
+		}
+	}
+	return pixels
+}
+
+// follows reports whether next is the cell to the right of prev on the same screen
+func (t *transition) follows(prev source, next source) bool {
+	return prev.screen == next.screen && prev.y == next.y && prev.x+1 == next.x
+}
+
+// pick returns the cell shown at x and y at factor of the transition
+func (t *transition) pick(x int, y int, factor float64) source {
+	horizontal := t.direction == Left || t.direction == Right
+	extent, pos := t.height, y
+	if horizontal {
+		extent, pos = t.width, x
+	}
+	reversed := t.direction == Left || t.direction == Up
+	// at returns the cell of screen at position a along the axis of motion,
+	// counted in the direction of the animation
+	at := func(screen int, a int) source {
+		if reversed {
+			a = extent - 1 - a
+		}
+		if horizontal {
+			return source{screen: screen, x: a, y: y}
+		}
+		return source{screen: screen, x: x, y: a}
+	}
+	along := pos
+	if reversed {
+		along = extent - 1 - pos
+	}
+	shift := int(math.Round(factor * float64(extent)))
+
+	switch t.kind {
+	case TransitionWipe:
+		if along < shift {
+			return at(1, along)
+		}
+		return at(0, along)
+	case TransitionSlide:
+		if along < shift {
+			return at(1, along+extent-shift)
+		}
+		return at(0, along-shift)
+	case TransitionDissolve:
+		if float64(t.order[y*t.width+x]) < factor*float64(len(t.order)) {
+			return source{screen: 1, x: x, y: y}
+		}
+		return source{screen: 0, x: x, y: y}
+	case TransitionFade:
+		if factor < 0.5 {
+			return source{screen: 0, x: x, y: y, dark: factor * 2}
+		}
+		return source{screen: 1, x: x, y: y, dark: (1 - factor) * 2}
+	}
+	// TransitionCurtain, both halves move by the same amount until the wider
+	// one is gone
+	half := extent / 2
+	open := int(math.Round(factor * float64(extent-half)))
+	curtain := func(p int) source {
+		if horizontal {
+			return source{screen: 0, x: p, y: y}
+		}
+		return source{screen: 0, x: x, y: p}
+	}
+	switch {
+	case pos < half && pos+open < half:
+		return curtain(pos + open)
+	case pos >= half && pos-open >= half:
+		return curtain(pos - open)
+	}
+	return source{screen: 1, x: x, y: y}
+}
+
+// darken returns pixel with its colors moved towards black by amount (0-1).
+// Blanks without a background are left as they are.
+func darken(pixel string, amount float64) string {
+	clusters := newANSIParser().parse(pixel)
+	if len(clusters) == 0 {
+		return pixel
+	}
+	cluster := clusters[0]
+	if strings.TrimSpace(cluster.text) == "" && cluster.style.Bg == DEFAULTCOLOR {
+		return pixel
+	}
+	theme := CurrentTheme()
+	style := cluster.style
+	style.Fg = PaletteColor(orColor(style.Fg, theme.Background.Fg)).Lerp(RGB{}, amount).Palette()
+	if style.Bg != DEFAULTCOLOR {
+		style.Bg = PaletteColor(style.Bg).Lerp(RGB{}, amount).Palette()
+	}
+	return style.Render(cluster.text)
+}
@@ -0,0 +1,148 @@
+package animaterm
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// screen builds a snapshot from rows of single width characters
+func screen(rows ...string) [][]string {
+	pixels := [][]string{}
+	for _, r := range rows {
+		pixels = append(pixels, strings.Split(r, ""))
+	}
+	return pixels
+}
+
+// rows joins the uncolored rows of a frame
+func rows(frame [][]string) []string {
+	joined := []string{}
+	for _, pixels := range frame {
+		joined = append(joined, stripANSI(strings.Join(pixels, "")))
+	}
+	return joined
+}
+
+func TestTransitionFrames(t *testing.T) {
+	from := screen("abcd", "efgh")
+	to := screen("ABCD", "EFGH")
+	tests := []struct {
+		name      string
+		kind      TransitionKind
+		direction Direction
+		factor    float64
+		want      []string
+	}{
+		{name: "wipe right", kind: TransitionWipe, direction: Right, factor: 0.5, want: []string{"ABcd", "EFgh"}},
+		{name: "wipe left", kind: TransitionWipe, direction: Left, factor: 0.25, want: []string{"abcD", "efgH"}},
+		{name: "wipe down", kind: TransitionWipe, direction: Down, factor: 0.5, want: []string{"ABCD", "efgh"}},
+		{name: "wipe up", kind: TransitionWipe, direction: Up, factor: 0.5, want: []string{"abcd", "EFGH"}},
+		{name: "slide right", kind: TransitionSlide, direction: Right, factor: 0.25, want: []string{"Dabc", "Hefg"}},
+		{name: "slide left", kind: TransitionSlide, direction: Left, factor: 0.5, want: []string{"cdAB", "ghEF"}},
+		{name: "slide down", kind: TransitionSlide, direction: Down, factor: 0.5, want: []string{"EFGH", "abcd"}},
+		{name: "curtain", kind: TransitionCurtain, direction: Right, factor: 0.5, want: []string{"bBCc", "fFGg"}},
+		{name: "curtain up", kind: TransitionCurtain, direction: Up, factor: 1, want: []string{"ABCD", "EFGH"}},
+		{name: "start", kind: TransitionSlide, direction: Right, factor: 0, want: []string{"abcd", "efgh"}},
+		{name: "end", kind: TransitionWipe, direction: Left, factor: 1, want: []string{"ABCD", "EFGH"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rows(newTransition(from, to, tt.kind, tt.direction).frame(tt.factor))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("frame(%v) = %q, want %q", tt.factor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransitionDissolve(t *testing.T) {
+	from := screen("aaaaaaaaaa", "aaaaaaaaaa")
+	to := screen("bbbbbbbbbb", "bbbbbbbbbb")
+	tr := newTransition(from, to, TransitionDissolve, Right)
+	for _, factor := range []float64{0, 0.3, 0.5, 1} {
+		got := strings.Count(strings.Join(rows(tr.frame(factor)), ""), "b")
+		if want := int(factor * 20); got != want {
+			t.Errorf("frame(%v) shows %d cells of the next screen, want %d", factor, got, want)
+		}
+	}
+	// revealed cells stay revealed
+	half, later := tr.frame(0.5), tr.frame(0.7)
+	for y := range half {
+		for x := range half[y] {
+			if half[y][x] == "b" && later[y][x] != "b" {
+				t.Errorf("cell %d,%d hidden again", x, y)
+			}
+		}
+	}
+}
+
+func TestTransitionFade(t *testing.T) {
+	from := [][]string{{Color("a", WHITE), " "}}
+	to := [][]string{{Color("b", WHITE), " "}}
+	tr := newTransition(from, to, TransitionFade, Right)
+	tests := []struct {
+		factor float64
+		want   string
+		fg     int
+	}{
+		{factor: 0, want: "a", fg: WHITE},
+		{factor: 0.5, want: "b", fg: 16},
+		{factor: 1, want: "b", fg: WHITE},
+	}
+	for _, tt := range tests {
+		frame := tr.frame(tt.factor)
+		clusters := newANSIParser().parse(frame[0][0])
+		if len(clusters) != 1 || clusters[0].text != tt.want || clusters[0].style.Fg != tt.fg {
+			t.Errorf("frame(%v) = %q, want %q in color %d", tt.factor, frame[0][0], tt.want, tt.fg)
+		}
+		if frame[0][1] != " " {
+			t.Errorf("frame(%v) colored a blank: %q", tt.factor, frame[0][1])
+		}
+	}
+}
+
+func TestTransitionWideCharacters(t *testing.T) {
+	from := [][]string{{"世", continuationCell, "a", "b"}}
+	to := [][]string{{"c", "d", "界", continuationCell}}
+	got := rows(newTransition(from, to, TransitionSlide, Right).frame(0.25))
+	// the next screen's 界 is cut off at the left edge, the current screen's
+	// 世 stays whole
+	if got[0] != " 世a" {
+		t.Errorf("slide = %q", got[0])
+	}
+	got = rows(newTransition(from, to, TransitionWipe, Right).frame(0.25))
+	if got[0] != "c ab" {
+		t.Errorf("wipe = %q", got[0])
+	}
+}
+
+func TestTransition(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	ui.DrawElement(CreatePos(0, 0), "before", ALREADYCOLORED)
+	err := ui.Transition(func(next IUserInterface) {
+		next.DrawElement(CreatePos(0, 0), "after", ALREADYCOLORED)
+	}, TransitionDissolve, Animation{})
+	if err != nil {
+		t.Fatalf("Transition returned error: %v", err)
+	}
+	if got := row(ui, 0, 0, 6); got != "after " {
+		t.Errorf("screen after the transition = %q", got)
+	}
+
+	// the next screen never writes to the terminal
+	var output io.Writer
+	err = ui.Transition(func(next IUserInterface) {
+		output = next.Output()
+	}, TransitionWipe, Animation{})
+	if err != nil || output != io.Discard {
+		t.Errorf("output of the next screen = %T, want io.Discard (err = %v)", output, err)
+	}
+
+	if err := ui.Transition(nil, TransitionWipe, Animation{}); err == nil {
+		t.Error("Transition without a scene should return an error")
+	}
+	if err := ui.Transition(func(IUserInterface) {}, TransitionKind(42), Animation{}); err == nil {
+		t.Error("Transition with an unknown kind should return an error")
+	}
+}
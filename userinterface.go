@@ -2,8 +2,10 @@ package animaterm
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,6 +28,13 @@ type UserInterface struct {
 	width           int
 	msPerFrame      int64
 	frameMutex      sync.RWMutex
+	resizeHandlers  []*resizeHandler
+	resizeMutex     sync.Mutex
+	tickers         []ITicker
+	tickerMutex     sync.Mutex
+	runMutex        sync.Mutex
+	output          io.Writer
+	outputMutex     sync.RWMutex
 }
 
 // CreateUI creates and initializes a new UserInterface instance.
@@ -88,15 +97,17 @@ func (ui *UserInterface) StartDrawLoop(percentHeight int) (chan int, *sync.WaitG
 	var wg sync.WaitGroup
 	wg.Add(1)
 	ch := make(chan int)
-	_, _ = p("\033[?25l")
+	_, _ = fmt.Fprint(ui.Output(), "\033[?25l")
 
-	go ui.drawLoop(Height()*percentHeight/100, Width(), ch, &wg)
+	go ui.drawLoop(percentHeight, ch, &wg)
 
 	return ch, &wg
 }
 
 // Draw ...
-func (ui *UserInterface) drawLoop(height int, width int, ch chan int, wg *sync.WaitGroup) {
+func (ui *UserInterface) drawLoop(percentHeight int, ch chan int, wg *sync.WaitGroup) {
+	width, height := ui.bufferSize()
+	height = height * percentHeight / 100
 	_ = time.Now()
 	_ = time.Duration(5)
 	lineBuffer := ""
@@ -106,12 +117,19 @@ func (ui *UserInterface) drawLoop(height int, width int, ch chan int, wg *sync.W
 		case _, ok := <-ch:
 			if !ok {
 				wg.Done()
-				_, _ = p("\033[?25h")
+				_, _ = fmt.Fprint(ui.Output(), "\033[?25h")
 				return
 			}
 		default:
 			_ = time.Now()
 
+			if ui.checkResize() {
+				width, height = ui.bufferSize()
+				height = height * percentHeight / 100
+				screenBuffer = ""
+			}
+			ui.runTickers(time.Now())
+
 			// Check if any regions are dirty before rebuilding buffer
 			ui.dirtyMutex.RLock()
 			hasDirty := false
@@ -148,15 +166,21 @@ func (ui *UserInterface) drawLoop(height int, width int, ch chan int, wg *sync.W
 					ui.frameMutex.Lock()
 					ui.msPerFrame = 30
 					ui.frameMutex.Unlock()
-					_, _ = pl(screenBuffer)
+					_, _ = fmt.Fprintln(ui.Output(), screenBuffer)
 					_ = ui.moveCursorTo(0, lastContentRow)
 					ui.clearDirtyRegions()
 				}
 			} else {
-				// No dirty regions, use slower frame rate
-				ui.frameMutex.Lock()
-				ui.msPerFrame = 320
-				ui.frameMutex.Unlock()
+				// No dirty regions, use slower frame rate unless tickers
+				// are waiting for their next frame
+				ui.tickerMutex.Lock()
+				idle := len(ui.tickers) == 0
+				ui.tickerMutex.Unlock()
+				if idle {
+					ui.frameMutex.Lock()
+					ui.msPerFrame = 320
+					ui.frameMutex.Unlock()
+				}
 			}
 			_ = time.Since(time.Now())
 			ui.frameMutex.RLock()
@@ -167,6 +191,99 @@ func (ui *UserInterface) drawLoop(height int, width int, ch chan int, wg *sync.W
 	}
 }
 
+// resizeHandler wraps a resize callback so that it can be told apart from
+// others when it is removed
+type resizeHandler struct {
+	callback func(width int, height int)
+}
+
+// OnResize registers a callback that is invoked from the draw loop whenever
+// the terminal size changed. The pixel buffer has already been reset to the
+// new size when the callback runs, so widgets are expected to redraw.
+// Calling the returned function removes the callback again.
+func (ui *UserInterface) OnResize(callback func(width int, height int)) func() {
+	handler := &resizeHandler{callback: callback}
+	ui.resizeMutex.Lock()
+	defer ui.resizeMutex.Unlock()
+	ui.resizeHandlers = append(ui.resizeHandlers, handler)
+	return func() {
+		ui.resizeMutex.Lock()
+		defer ui.resizeMutex.Unlock()
+		ui.resizeHandlers = slices.DeleteFunc(ui.resizeHandlers, func(h *resizeHandler) bool { return h == handler })
+	}
+}
+
+// AddTicker registers a ticker that the draw loop advances once per frame
+// until its Tick method returns false
+func (ui *UserInterface) AddTicker(ticker ITicker) {
+	ui.tickerMutex.Lock()
+	defer ui.tickerMutex.Unlock()
+	ui.tickers = append(ui.tickers, ticker)
+}
+
+// runTickers advances all registered tickers and drops the finished ones.
+// Tickers run without holding the lock so they may register new tickers.
+// New registrations are appended behind the tickers that ran, so finished
+// ones are dropped by their index: a ticker registering again while it
+// finishes is kept.
+func (ui *UserInterface) runTickers(now time.Time) {
+	ui.runMutex.Lock()
+	defer ui.runMutex.Unlock()
+
+	ui.tickerMutex.Lock()
+	tickers := append([]ITicker{}, ui.tickers...)
+	ui.tickerMutex.Unlock()
+
+	finished := make([]bool, len(tickers))
+	anyFinished := false
+	for i, ticker := range tickers {
+		if !ticker.Tick(now) {
+			finished[i] = true
+			anyFinished = true
+		}
+	}
+	if !anyFinished {
+		return
+	}
+
+	ui.tickerMutex.Lock()
+	defer ui.tickerMutex.Unlock()
+	remaining := ui.tickers[:0]
+	for i, ticker := range ui.tickers {
+		if i >= len(finished) || !finished[i] {
+			remaining = append(remaining, ticker)
+		}
+	}
+	ui.tickers = remaining
+}
+
+// checkResize compares the buffer with the current terminal size and
+// reinitializes it on a mismatch. Returns true if the size changed.
+func (ui *UserInterface) checkResize() bool {
+	bufferWidth, bufferHeight := ui.bufferSize()
+	width, height := Width(), Height()
+	if width == bufferWidth && height == bufferHeight {
+		return false
+	}
+	_ = ui.initPixels(height, width)
+	_, _ = fmt.Fprint(ui.Output(), "\033[2J")
+
+	ui.resizeMutex.Lock()
+	handlers := append([]*resizeHandler{}, ui.resizeHandlers...)
+	ui.resizeMutex.Unlock()
+	for _, handler := range handlers {
+		handler.callback(width, height)
+	}
+	return true
+}
+
+// bufferSize returns the width and height of the pixel buffer
+func (ui *UserInterface) bufferSize() (int, int) {
+	ui.pixelsMutex.RLock()
+	defer ui.pixelsMutex.RUnlock()
+	return ui.width, ui.height
+}
+
 // SetBorderLeft ...
 // Set a global border on left side of screen that forces
 // all elements to be printed outside it's boundaries
@@ -174,7 +291,8 @@ func (ui *UserInterface) SetBorderLeft(percent int) error {
 	if percent < 0 || percent > 50 {
 		return fmt.Errorf("border percent must be between 0 and 50, got %d", percent)
 	}
-	ui.absBorderLeft = Width() * percent / 100
+	width, _ := ui.bufferSize()
+	ui.absBorderLeft = width * percent / 100
 	return nil
 }
 
@@ -185,7 +303,8 @@ func (ui *UserInterface) SetBorderRight(percent int) error {
 	if percent < 0 || percent > 50 {
 		return fmt.Errorf("border percent must be between 0 and 50, got %d", percent)
 	}
-	ui.absBorderRight = Width() * percent / 100
+	width, _ := ui.bufferSize()
+	ui.absBorderRight = width * percent / 100
 	return nil
 }
 
@@ -196,7 +315,8 @@ func (ui *UserInterface) SetBorderTop(percent int) error {
 	if percent < 0 || percent > 50 {
 		return fmt.Errorf("border percent must be between 0 and 50, got %d", percent)
 	}
-	ui.absBorderTop = Height() * percent / 100
+	_, height := ui.bufferSize()
+	ui.absBorderTop = height * percent / 100
 	return nil
 }
 
@@ -207,7 +327,8 @@ func (ui *UserInterface) SetBorderBottom(percent int) error {
 	if percent < 0 || percent > 50 {
 		return fmt.Errorf("border percent must be between 0 and 50, got %d", percent)
 	}
-	ui.absBorderBottom = Height() * percent / 100
+	_, height := ui.bufferSize()
+	ui.absBorderBottom = height * percent / 100
 	return nil
 }
 
@@ -251,10 +372,28 @@ func (ui *UserInterface) DrawTable(pos IRelativePosition, table [][]string, posi
 	return y
 }
 
+// DrawTableScrolled see IUserInterface
+func (ui *UserInterface) DrawTableScrolled(pos IRelativePosition, table [][]string, positions []int, colors []int, offset int, visibleRows int) int {
+	visibleRows = max(visibleRows, 0)
+	offset = clampOffset(offset, len(table), visibleRows)
+	end := min(offset+visibleRows, len(table))
+	start := pos.GetOffset()
+	y := ui.DrawTable(pos, table[offset:end], positions, colors)
+	if len(table) > visibleRows {
+		_, row := pos.Coords()
+		bar := CreateMixedPos(Coord{Percent: 100, Cells: -1}, row)
+		for i, cell := range scrollbar(visibleRows, len(table), offset) {
+			bar.SetOffset(start + i)
+			ui.DrawElement(bar, cell, ALREADYCOLORED)
+		}
+	}
+	return y
+}
+
 // DrawElementsHorizontal ...
 func (ui *UserInterface) DrawElementsHorizontal(pos IRelativePosition, texts []string, positions []int, colors []int) int {
 	y, y1 := 0, 0
-	newPos := CreatePos(pos.GetX(), pos.GetY())
+	newPos := CreateMixedPos(pos.Coords())
 	for k, s := range texts {
 		newPos.SetOffset(pos.GetOffset() + int(k/len(positions)))
 		y1 = ui.DrawElement(newPos.AddDistance(CreatePos(positions[k%len(positions)], 0)), s, colors[k%len(positions)])
@@ -267,21 +406,108 @@ func (ui *UserInterface) DrawElementsHorizontal(pos IRelativePosition, texts []s
 
 // DrawElement renders text at the specified position with the given color.
 // Supports multi-line text and automatically handles line wrapping.
+// The bounding box of the text is aligned to pos according to its anchor.
+// With ALREADYCOLORED the escape sequences inside text are interpreted, so
+// every cell keeps the style it was given. With MARKUP style tags are
+// interpreted, see Markup.
 // Returns the Y coordinate of the last rendered line.
 func (ui *UserInterface) DrawElement(pos IRelativePosition, text string, color int) int {
+	text, color = resolveMarkup(text, color)
 	x, y := 0, 0
+	shiftX, shiftY := pos.GetAnchor().shift(measureText(text))
+	screen := Rect{Width: ui.width, Height: ui.height}
+	parser := newANSIParser()
 	for k, line := range getLines(text, color == BLANK) {
-		for l, c := range line {
-			y = (ui.PercentToAbsoluteHeightInFrame(pos.GetY()) + pos.GetOffset() + ui.absBorderTop) % ui.height
-			x = (ui.PercentToAbsoluteWidthInFrame(pos.GetX()) + l + ui.absBorderLeft) % ui.width
+		lineColor := color
+		if color < RESET {
+			lineColor = color + k
+		}
+		l := 0
+		for _, cluster := range ui.styleClusters(parser, line, lineColor) {
+			absX, absY := ui.PositionToAbsolute(pos)
+			y = (absY + shiftY) % ui.height
+			x = (absX + l + shiftX) % ui.width
 
-			ui.setPixel(x, y, Color(string(c), color+k))
+			l += ui.placeCluster(screen, x, y, cluster)
 		}
 		pos.IncrementOffset()
 	}
 	return y - 1
 }
 
+// DrawClipped renders a single line of text starting at the absolute cell
+// coordinates x and y. Cells falling outside of clip are discarded, which lets
+// widgets draw into their own region without bleeding into neighbours.
+// Returns the number of cells the text advanced.
+func (ui *UserInterface) DrawClipped(clip Rect, x int, y int, text string, color int) int {
+	text, color = resolveMarkup(text, color)
+	l := 0
+	for _, cluster := range ui.styleClusters(newANSIParser(), getLines(text, color == BLANK)[0], color) {
+		l += ui.placeCluster(clip, x+l, y, cluster)
+	}
+	return l
+}
+
+// resolveMarkup converts text drawn with MARKUP into pre-colored text
+func resolveMarkup(text string, color int) (string, int) {
+	if color == MARKUP {
+		return Markup(text), ALREADYCOLORED
+	}
+	return text, color
+}
+
+// renderedCluster is a grapheme cluster and the pixel value it is stored as
+type renderedCluster struct {
+	text  string
+	pixel func(text string) string
+}
+
+// styleClusters splits a line into grapheme clusters rendered in color. With
+// ALREADYCOLORED the escape sequences of the line are interpreted by parser.
+func (ui *UserInterface) styleClusters(parser *ansiParser, line string, color int) []renderedCluster {
+	clusters := []renderedCluster{}
+	if color == ALREADYCOLORED {
+		for _, cluster := range parser.parse(line) {
+			clusters = append(clusters, renderedCluster{cluster.text, cluster.style.Render})
+		}
+		return clusters
+	}
+	paint := func(text string) string {
+		return Color(text, color)
+	}
+	for _, cluster := range graphemes(line) {
+		clusters = append(clusters, renderedCluster{cluster, paint})
+	}
+	return clusters
+}
+
+// drawCluster places a grapheme cluster drawn in color at x and y, see placeCluster
+func (ui *UserInterface) drawCluster(clip Rect, x int, y int, cluster string, color int) int {
+	return ui.placeCluster(clip, x, y, renderedCluster{cluster, func(text string) string {
+		return Color(text, color)
+	}})
+}
+
+// placeCluster places a grapheme cluster at x and y if it lies inside clip.
+// Wide clusters also claim the cell to their right, which is marked as a
+// continuation; a wide cluster cut in half by clip leaves a space instead.
+// Returns the number of cells the cluster occupies.
+func (ui *UserInterface) placeCluster(clip Rect, x int, y int, cluster renderedCluster) int {
+	width := clusterWidth(cluster.text)
+	switch {
+	case width == 2 && clip.Contains(x, y) && clip.Contains(x+1, y):
+		ui.setPixel(x, y, cluster.pixel(cluster.text))
+		ui.setPixel(x+1, y, continuationCell)
+	case width == 2 && clip.Contains(x, y):
+		ui.setPixel(x, y, cluster.pixel(" "))
+	case width == 2 && clip.Contains(x+1, y):
+		ui.setPixel(x+1, y, cluster.pixel(" "))
+	case width == 1 && clip.Contains(x, y):
+		ui.setPixel(x, y, cluster.pixel(cluster.text))
+	}
+	return width
+}
+
 // MoveElement animates text movement from startPos to endPos over the specified duration.
 // Supports gradient effects and various animation curves (EaseIn, EaseOut, etc.).
 // The animation blocks until completion.
@@ -295,6 +521,8 @@ func (ui *UserInterface) MoveElement(startPos IRelativePosition, endPos IRelativ
 	if animation.Duration < 0 {
 		return fmt.Errorf("animation duration cannot be negative")
 	}
+	// erase with the width of the visible text, not of the tags
+	text, color = resolveMarkup(text, color)
 
 	ui.frameMutex.RLock()
 	frameRate := ui.msPerFrame
@@ -311,8 +539,11 @@ func (ui *UserInterface) MoveElement(startPos IRelativePosition, endPos IRelativ
 		ui.DrawElement(startPos.AddDistance(distance.MultiplyWith(factor)), text, BLANK)
 		factor = getAnimation(animation.AnimationType)(clock.Time(0), clock.Time(frames), clock.Time(i))
 
-		if animation.GradientV || animation.GradientH {
-			ui.DrawElement(startPos.AddDistance(distance.MultiplyWith(factor)), text, color+36*int(float32(5)*factor))
+		if animation.Gradient != nil {
+			elapsed := time.Duration(int64(i)*frameRate) * time.Millisecond
+			ui.DrawElement(startPos.AddDistance(distance.MultiplyWith(factor)), animation.Gradient.Elapsed(elapsed).Apply(text), ALREADYCOLORED)
+		} else if (animation.GradientV || animation.GradientH) && color < RESET {
+			ui.DrawElement(startPos.AddDistance(distance.MultiplyWith(factor)), text, gradientStep(color, factor))
 		} else {
 			ui.DrawElement(startPos.AddDistance(distance.MultiplyWith(factor)), text, color)
 		}
@@ -341,27 +572,42 @@ func (ui *UserInterface) DrawPattern(startPos IRelativePosition, expansion int,
 	}
 
 	y := 0
-	startAbsWidth := ui.PercentToAbsoluteWidthInFrame(startPos.GetX()) + ui.absBorderLeft
-	startAbsHeight := ui.PercentToAbsoluteHeightInFrame(startPos.GetY()) + ui.absBorderTop + startPos.GetOffset()
+	startAbsWidth, startAbsHeight := ui.PositionToAbsolute(startPos)
 
-	drawPixel := func(h int, w int, factorColor float32, expander []int) {
+	lines := getLines(text, false)
+	start := time.Now()
+	drawPixel := func(h int, w int, factorColor float32, along float64, expander []int) {
 		basecolor := color
 		if animation.GradientV {
-			basecolor = color + int(float32(5)*factorColor)
+			basecolor = gradientStep(color, factorColor)
+		}
+		var gradient Gradient
+		if animation.Gradient != nil {
+			gradient = animation.Gradient.Elapsed(time.Since(start))
 		}
-		for k, line := range getLines(text, false) {
+		screen := Rect{Width: ui.width, Height: ui.height}
+		for k, line := range lines {
+			if line == "" {
+				continue
+			}
 			expH := expander[0] * k
 			expW := expander[1] * k
-			line = string([]rune(line)[0])
+			line = graphemes(line)[0]
 			yPos := (h + expH) % ui.height
 			xPos := (w + expW) % ui.width
-			var coloredLine string
-			if animation.GradientH {
-				coloredLine = Color(line, (basecolor+(k*36))%255)
+			if animation.Gradient != nil {
+				// the pattern expands along x for Right and Left and along y otherwise
+				across := fraction(k, len(lines))
+				fx, fy := along, across
+				if expander[1] == 1 {
+					fx, fy = across, along
+				}
+				ui.drawCluster(screen, xPos, yPos, line, gradient.in(fx, fy).Palette())
+			} else if animation.GradientH {
+				ui.drawCluster(screen, xPos, yPos, line, gradientStep(basecolor, float32(k)/float32(max(len(lines)-1, 1))))
 			} else {
-				coloredLine = Color(line, basecolor)
+				ui.drawCluster(screen, xPos, yPos, line, basecolor)
 			}
-			ui.setPixel(xPos, yPos, coloredLine)
 			if h+expH > y {
 				y = h + expH
 			}
@@ -399,7 +645,7 @@ func (ui *UserInterface) DrawPattern(startPos IRelativePosition, expansion int,
 
 		for counter := 0; counter <= expAbs; counter++ {
 			factorColor := getAnimation(animation.AnimationType)(clock.Time(0), clock.Time(expAbs), clock.Time(counter))
-			drawPixel(startAbsHeight+expDir2[0]*counter, startAbsWidth+expDir2[1]*counter, factorColor, expDir1)
+			drawPixel(startAbsHeight+expDir2[0]*counter, startAbsWidth+expDir2[1]*counter, factorColor, fraction(counter, expAbs+1), expDir1)
 		}
 
 	}
@@ -434,32 +680,51 @@ func (ui *UserInterface) DrawPattern(startPos IRelativePosition, expansion int,
 func getLines(multilineText string, replaceWithBlanks bool) []string {
 	var lines = []string{}
 	for _, line := range strings.Split(strings.TrimSuffix(multilineText, "\n"), "\n") {
+		line = expandTabs(line)
 		if replaceWithBlanks {
-			line = strings.Repeat(" ", len(fmt.Sprintf("%v", line)))
+			line = strings.Repeat(" ", displayWidth(stripANSI(line)))
 		}
 		lines = append(lines, line)
 	}
 	return lines
 }
 
+// measureText returns the width of the widest line and the number of lines
+func measureText(text string) (int, int) {
+	lines := getLines(text, false)
+	width := 0
+	for _, line := range lines {
+		if w := displayWidth(stripANSI(line)); w > width {
+			width = w
+		}
+	}
+	return width, len(lines)
+}
+
 // PercentToAbsoluteWidth ...
 func (ui *UserInterface) PercentToAbsoluteWidth(percent int) int {
-	return Width() * percent / 100
+	width, _ := ui.bufferSize()
+	return width * percent / 100
 }
 
 // PercentToAbsoluteHeight ...
 func (ui *UserInterface) PercentToAbsoluteHeight(percent int) int {
-	return (Height() * percent / 100)
+	_, height := ui.bufferSize()
+	return height * percent / 100
 }
 
 // GetAbsFrameWidth ...
+// The frame is measured on the pixel buffer, which follows the terminal size
+// through checkResize, so positions always match what is drawn.
 func (ui *UserInterface) GetAbsFrameWidth() int {
-	return Width() - ui.absBorderLeft - ui.absBorderRight
+	width, _ := ui.bufferSize()
+	return width - ui.absBorderLeft - ui.absBorderRight
 }
 
 // GetAbsFrameHeight ...
 func (ui *UserInterface) GetAbsFrameHeight() int {
-	return Height() - ui.absBorderTop - ui.absBorderBottom
+	_, height := ui.bufferSize()
+	return height - ui.absBorderTop - ui.absBorderBottom
 }
 
 // PercentToAbsoluteWidthInFrame ...
@@ -482,16 +747,46 @@ func (ui *UserInterface) PercentToAbsoluteYPostion(percent int) int {
 	return (ui.GetAbsFrameHeight() * percent / 100) + ui.absBorderTop
 }
 
+// PositionToAbsolute ...
+func (ui *UserInterface) PositionToAbsolute(pos IRelativePosition) (int, int) {
+	x, y := pos.Coords()
+	return x.resolve(ui.GetAbsFrameWidth()) + ui.absBorderLeft, y.resolve(ui.GetAbsFrameHeight()) + ui.absBorderTop + pos.GetOffset()
+}
+
 // ClearScreen ...
 func (ui *UserInterface) ClearScreen() error {
 	_ = ui.initPixels(Height(), Width())
 	cmd := exec.Command("clear", "cmd", "/c", "cls")
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = ui.Output()
 	_ = cmd.Run()
 	return nil
 }
 
-// setPixel safely sets a pixel and marks the region as dirty
+// Output see IUserInterface
+func (ui *UserInterface) Output() io.Writer {
+	ui.outputMutex.RLock()
+	defer ui.outputMutex.RUnlock()
+	if ui.output == nil {
+		return os.Stdout
+	}
+	return ui.output
+}
+
+// SetOutput see IUserInterface
+func (ui *UserInterface) SetOutput(w io.Writer) {
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+	ui.output = w
+}
+
+// Size see IUserInterface
+func (ui *UserInterface) Size() (int, int) {
+	return ui.bufferSize()
+}
+
+// setPixel safely sets a pixel and marks the region as dirty.
+// Overwriting either half of a wide cluster blanks its other half, so no
+// stray continuation or half character is left behind.
 func (ui *UserInterface) setPixel(x, y int, value string) {
 	ui.pixelsMutex.Lock()
 	ui.dirtyMutex.Lock()
@@ -499,13 +794,51 @@ func (ui *UserInterface) setPixel(x, y int, value string) {
 	defer ui.dirtyMutex.Unlock()
 
 	if y >= 0 && y < len(ui.pixels) && x >= 0 && x < len(ui.pixels[y]) {
-		if ui.pixels[y][x] != value {
-			ui.pixels[y][x] = value
+		row := ui.pixels[y]
+		if row[x] != value {
+			if value != continuationCell && row[x] == continuationCell && x > 0 {
+				row[x-1] = " "
+				ui.dirtyRegions[y][x-1] = true
+			}
+			if x+1 < ui.width && row[x+1] == continuationCell {
+				row[x+1] = " "
+				ui.dirtyRegions[y][x+1] = true
+			}
+			row[x] = value
 			ui.dirtyRegions[y][x] = true
 		}
 	}
 }
 
+// Snapshot see IUserInterface
+func (ui *UserInterface) Snapshot(rect Rect) [][]string {
+	ui.pixelsMutex.RLock()
+	defer ui.pixelsMutex.RUnlock()
+
+	snapshot := make([][]string, max(rect.Height, 0))
+	for row := range snapshot {
+		snapshot[row] = make([]string, max(rect.Width, 0))
+		y := rect.Y + row
+		for column := range snapshot[row] {
+			x := rect.X + column
+			snapshot[row][column] = " "
+			if y >= 0 && y < ui.height && x >= 0 && x < ui.width {
+				snapshot[row][column] = ui.pixels[y][x]
+			}
+		}
+	}
+	return snapshot
+}
+
+// Restore see IUserInterface
+func (ui *UserInterface) Restore(x int, y int, snapshot [][]string) {
+	for row, pixels := range snapshot {
+		for column, pixel := range pixels {
+			ui.setPixel(x+column, y+row, pixel)
+		}
+	}
+}
+
 // clearDirtyRegions resets all dirty flags
 func (ui *UserInterface) clearDirtyRegions() {
 	ui.dirtyMutex.Lock()
@@ -519,6 +852,6 @@ func (ui *UserInterface) clearDirtyRegions() {
 }
 
 func (ui *UserInterface) moveCursorTo(absX int, absY int) error {
-	_, _ = p("\033[" + strconv.Itoa(absY) + ";" + strconv.Itoa(absX) + "H")
+	_, _ = fmt.Fprint(ui.Output(), "\033["+strconv.Itoa(absY)+";"+strconv.Itoa(absX)+"H")
 	return nil
 }
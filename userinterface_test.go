@@ -2,6 +2,7 @@ package animaterm
 
 import (
 	"testing"
+	"time"
 )
 
 func TestCreateUI(t *testing.T) {
@@ -169,3 +170,82 @@ func TestSetPixelSafety(t *testing.T) {
 	}
 	ui.pixelsMutex.RUnlock()
 }
+
+func TestCheckResize(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+
+	if ui.checkResize() {
+		t.Error("checkResize() reported a resize without a size change")
+	}
+
+	calls := 0
+	ui.OnResize(func(width int, height int) {
+		calls++
+		if width != Width() || height != Height() {
+			t.Errorf("resize handler got %dx%d, want %dx%d", width, height, Width(), Height())
+		}
+	})
+	_ = ui.initPixels(5, 5)
+
+	if !ui.checkResize() {
+		t.Error("checkResize() missed a size change")
+	}
+	if calls != 1 {
+		t.Errorf("resize handler called %d times, want 1", calls)
+	}
+	if w, h := ui.bufferSize(); w != Width() || h != Height() {
+		t.Errorf("buffer is %dx%d after resize, want %dx%d", w, h, Width(), Height())
+	}
+}
+
+func TestDrawElementAnchor(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.SetBorder(0)
+
+	// a 4x2 element centered on the middle of the frame
+	ui.DrawElement(CreatePos(50, 50).SetAnchor(AnchorCenter), "abcd\nefgh", ALREADYCOLORED)
+	x, y := ui.PercentToAbsoluteXPostion(50), ui.PercentToAbsoluteYPostion(50)
+	if ui.pixels[y-1][x-2] != "a" || ui.pixels[y][x+1] != "h" {
+		t.Errorf("centered element misplaced: %q at top-left, %q at bottom-right", ui.pixels[y-1][x-2], ui.pixels[y][x+1])
+	}
+
+	ui.DrawElement(CreatePos(100, 100).SetAnchor(AnchorBottomRight), "xy", ALREADYCOLORED)
+	if ui.pixels[ui.height-1][ui.width-1] != "y" {
+		t.Errorf("bottom-right anchored element not in the corner: %q", ui.pixels[ui.height-1][ui.width-1])
+	}
+}
+
+// tickerFunc is a ticker of a type that cannot be used as a map key
+type tickerFunc func(now time.Time) bool
+
+func (f tickerFunc) Tick(now time.Time) bool {
+	return f(now)
+}
+
+func TestRunTickers(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	ticks := 0
+	var again ITicker
+	again = tickerFunc(func(time.Time) bool {
+		ticks++
+		if ticks == 1 {
+			// registers itself again while finishing
+			ui.AddTicker(again)
+		}
+		return false
+	})
+	ui.AddTicker(tickerFunc(func(time.Time) bool { return true }))
+	ui.AddTicker(again)
+
+	ui.runTickers(time.Now())
+	if len(ui.tickers) != 2 {
+		t.Fatalf("%d tickers after the first run, want the running one and the re-registered one", len(ui.tickers))
+	}
+	ui.runTickers(time.Now())
+	if ticks != 2 {
+		t.Errorf("re-registered ticker ticked %d times, want 2", ticks)
+	}
+	if len(ui.tickers) != 1 {
+		t.Errorf("%d tickers after the second run, want 1", len(ui.tickers))
+	}
+}
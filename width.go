@@ -0,0 +1,210 @@
+package animaterm
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tabWidth is the distance between two tab stops. Tabs are expanded to
+// spaces before text is measured or drawn, see expandTabs.
+const tabWidth = 8
+
+// continuationCell is stored in the pixel right of a wide grapheme cluster.
+// It renders as nothing, since the terminal already advanced two columns.
+const continuationCell = ""
+
+// wideRanges lists the code points occupying two cells: East Asian wide and
+// fullwidth characters as well as emoji with default emoji presentation
+var wideRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x1100, 0x115f, 1}, {0x231a, 0x231b, 1}, {0x2329, 0x232a, 1},
+		{0x23e9, 0x23ec, 1}, {0x23f0, 0x23f0, 1}, {0x23f3, 0x23f3, 1},
+		{0x25fd, 0x25fe, 1}, {0x2614, 0x2615, 1}, {0x2648, 0x2653, 1},
+		{0x267f, 0x267f, 1}, {0x2693, 0x2693, 1}, {0x26a1, 0x26a1, 1},
+		{0x26aa, 0x26ab, 1}, {0x26bd, 0x26be, 1}, {0x26c4, 0x26c5, 1},
+		{0x26ce, 0x26ce, 1}, {0x26d4, 0x26d4, 1}, {0x26ea, 0x26ea, 1},
+		{0x26f2, 0x26f3, 1}, {0x26f5, 0x26f5, 1}, {0x26fa, 0x26fa, 1},
+		{0x26fd, 0x26fd, 1}, {0x2705, 0x2705, 1}, {0x270a, 0x270b, 1},
+		{0x2728, 0x2728, 1}, {0x274c, 0x274c, 1}, {0x274e, 0x274e, 1},
+		{0x2753, 0x2755, 1}, {0x2757, 0x2757, 1}, {0x2795, 0x2797, 1},
+		{0x27b0, 0x27b0, 1}, {0x27bf, 0x27bf, 1}, {0x2b1b, 0x2b1c, 1},
+		{0x2b50, 0x2b50, 1}, {0x2b55, 0x2b55, 1}, {0x2e80, 0x303e, 1},
+		{0x3041, 0x33ff, 1}, {0x3400, 0x4dbf, 1}, {0x4e00, 0x9fff, 1},
+		{0xa000, 0xa4cf, 1}, {0xa960, 0xa97f, 1}, {0xac00, 0xd7a3, 1},
+		{0xf900, 0xfaff, 1}, {0xfe10, 0xfe19, 1}, {0xfe30, 0xfe6f, 1},
+		{0xff00, 0xff60, 1}, {0xffe0, 0xffe6, 1},
+	},
+	R32: []unicode.Range32{
+		{0x16fe0, 0x16fe4, 1}, {0x17000, 0x18aff, 1}, {0x1b000, 0x1b2ff, 1},
+		{0x1f004, 0x1f004, 1}, {0x1f0cf, 0x1f0cf, 1}, {0x1f18e, 0x1f18e, 1},
+		{0x1f191, 0x1f19a, 1}, {0x1f1e6, 0x1f1ff, 1}, {0x1f200, 0x1f202, 1},
+		{0x1f210, 0x1f23b, 1}, {0x1f240, 0x1f248, 1}, {0x1f250, 0x1f251, 1},
+		{0x1f260, 0x1f265, 1}, {0x1f300, 0x1f320, 1}, {0x1f32d, 0x1f335, 1},
+		{0x1f337, 0x1f37c, 1}, {0x1f37e, 0x1f393, 1}, {0x1f3a0, 0x1f3ca, 1},
+		{0x1f3cf, 0x1f3d3, 1}, {0x1f3e0, 0x1f3f0, 1}, {0x1f3f4, 0x1f3f4, 1},
+		{0x1f3f8, 0x1f43e, 1}, {0x1f440, 0x1f440, 1}, {0x1f442, 0x1f4fc, 1},
+		{0x1f4ff, 0x1f53d, 1}, {0x1f54b, 0x1f54e, 1}, {0x1f550, 0x1f567, 1},
+		{0x1f57a, 0x1f57a, 1}, {0x1f595, 0x1f596, 1}, {0x1f5a4, 0x1f5a4, 1},
+		{0x1f5fb, 0x1f64f, 1}, {0x1f680, 0x1f6c5, 1}, {0x1f6cc, 0x1f6cc, 1},
+		{0x1f6d0, 0x1f6d2, 1}, {0x1f6d5, 0x1f6d7, 1}, {0x1f6dc, 0x1f6df, 1},
+		{0x1f6eb, 0x1f6ec, 1}, {0x1f6f4, 0x1f6fc, 1}, {0x1f7e0, 0x1f7eb, 1},
+		{0x1f7f0, 0x1f7f0, 1}, {0x1f90c, 0x1f93a, 1}, {0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1f9ff, 1}, {0x1fa70, 0x1faff, 1}, {0x20000, 0x2fffd, 1},
+		{0x30000, 0x3fffd, 1},
+	},
+}
+
+// runeWidth returns the number of cells r occupies on its own
+func runeWidth(r rune) int {
+	switch {
+	case unicode.IsControl(r) || isExtend(r) || unicode.Is(unicode.Cf, r):
+		return 0
+	case unicode.Is(wideRanges, r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// isExtend reports whether r attaches to the preceding rune of a grapheme
+// cluster: nonspacing and enclosing marks, variation selectors, emoji
+// modifiers, tags, the zero width joiner and Hangul medial and final jamo.
+// Spacing marks such as Indic vowel signs occupy a cell of their own and are
+// therefore kept as separate clusters.
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == 0x200d ||
+		(r >= 0x1160 && r <= 0x11ff) ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) ||
+		(r >= 0xe0020 && r <= 0xe007f)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// graphemes splits s into grapheme clusters. This is a simplification of
+// UAX #29 covering combining sequences, emoji ZWJ sequences, emoji modifiers,
+// flags made of regional indicator pairs, Hangul jamo and CR LF.
+func graphemes(s string) []string {
+	clusters := []string{}
+	start := 0
+	prev := rune(-1)
+	regional := 0
+	for i, r := range s {
+		joins := false
+		switch {
+		case i == 0:
+		case prev == '\r' && r == '\n':
+			joins = true
+		case isExtend(r):
+			joins = true
+		case prev == 0x200d:
+			joins = true
+		case isRegionalIndicator(r) && isRegionalIndicator(prev) && regional%2 == 1:
+			joins = true
+		}
+		if !joins && i > 0 {
+			clusters = append(clusters, s[start:i])
+			start = i
+		}
+		if isRegionalIndicator(r) {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = r
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// clusterWidth returns the number of cells a grapheme cluster occupies. It is
+// the width of its base character, widened to two cells by an emoji
+// presentation selector.
+func clusterWidth(cluster string) int {
+	base, size := utf8.DecodeRuneInString(cluster)
+	width := runeWidth(base)
+	for _, r := range cluster[size:] {
+		if r == 0xfe0f && width == 1 {
+			width = 2
+		}
+	}
+	return width
+}
+
+// displayWidth returns the number of cells s occupies. Tabs advance to the
+// next tab stop counted from the start of s, escape sequences take no room.
+func displayWidth(s string) int {
+	width := 0
+	for _, cluster := range graphemes(stripANSI(s)) {
+		if cluster == "\t" {
+			width += tabWidth - width%tabWidth
+			continue
+		}
+		width += clusterWidth(cluster)
+	}
+	return width
+}
+
+// expandTabs replaces the tabs in s by spaces up to the next tab stop,
+// counted in cells from the start of s. Escape sequences take no room.
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var sb strings.Builder
+	width := 0
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '\033':
+			length, _, _ := scanEscape(s[i:])
+			sb.WriteString(s[i : i+length])
+			i += length
+		case '\t':
+			spaces := tabWidth - width%tabWidth
+			sb.WriteString(strings.Repeat(" ", spaces))
+			width += spaces
+			i++
+		default:
+			end := i + 1
+			for end < len(s) && s[end] != '\033' && s[end] != '\t' {
+				end++
+			}
+			sb.WriteString(s[i:end])
+			width += displayWidth(s[i:end])
+			i = end
+		}
+	}
+	return sb.String()
+}
+
+// truncateCells returns the longest prefix of s that fits into width cells
+// without splitting a grapheme cluster. Escape sequences take no room and are
+// kept up to the cut.
+func truncateCells(s string, width int) string {
+	used, end := 0, 0
+	for end < len(s) {
+		if s[end] == '\033' {
+			length, _, _ := scanEscape(s[end:])
+			end += length
+			continue
+		}
+		run := s[end:]
+		if i := strings.IndexByte(run, '\033'); i >= 0 {
+			run = run[:i]
+		}
+		for _, cluster := range graphemes(run) {
+			w := clusterWidth(cluster)
+			if used+w > width {
+				return s[:end]
+			}
+			used += w
+			end += len(cluster)
+		}
+	}
+	return s
+}
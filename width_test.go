@@ -0,0 +1,126 @@
+package animaterm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"ASCII", "abc", []string{"a", "b", "c"}},
+		{"Combining marks", "éạ̈", []string{"é", "ạ̈"}},
+		{"ZWJ sequence", "👩‍💻!", []string{"👩‍💻", "!"}},
+		{"Skin tone", "👍🏽x", []string{"👍🏽", "x"}},
+		{"Flags", "🇩🇪🇫🇷🇮", []string{"🇩🇪", "🇫🇷", "🇮"}},
+		{"Hangul jamo", "각가", []string{"각", "가"}},
+		{"CRLF", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"Spacing marks", "किताब", []string{"क", "ि", "त", "ा", "ब"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := graphemes(tt.text)
+			if strings.Join(result, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("graphemes(%q) = %q, want %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"hello", 5},
+		{"日本語", 6},
+		{"ｈｉ", 4},
+		{"किताब", 5},
+		{"नमस्ते", 4},
+		{"é", 1},
+		{"👩‍💻", 2},
+		{"🇩🇪", 2},
+		{"❤", 1},
+		{"❤️", 2},
+		{"█▏", 2},
+		{"a​b", 2},
+		{"a\tb", 9},
+		{"\t日本\t", 16},
+	}
+
+	for _, tt := range tests {
+		if result := displayWidth(tt.text); result != tt.expected {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.text, result, tt.expected)
+		}
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"a\tb", "a       b"},
+		{"\t", "        "},
+		{"日本語\tx", "日本語  x"},
+		{"\033[31mab\033[0m\tc", "\033[31mab\033[0m      c"},
+		{"12345678\tx", "12345678        x"},
+	}
+
+	for _, tt := range tests {
+		if result := expandTabs(tt.text); result != tt.expected {
+			t.Errorf("expandTabs(%q) = %q, want %q", tt.text, result, tt.expected)
+		}
+	}
+
+	ui := CreateUI().(*UserInterface)
+	_ = ui.initPixels(24, 80)
+	ui.DrawElement(CreatePos(0, 0), "a\tb", WHITE)
+	if got := stripANSI(row(ui, 0, 0, 10)); got != "a       b " {
+		t.Errorf("drawn row = %q", got)
+	}
+}
+
+func TestTruncateCellsKeepsClusters(t *testing.T) {
+	if result := truncateCells("日本語", 5); result != "日本" {
+		t.Errorf("truncateCells() = %q, want %q", result, "日本")
+	}
+	if result := truncateCells("éé", 1); result != "é" {
+		t.Errorf("truncateCells() = %q, want %q", result, "é")
+	}
+}
+
+func TestWideClusterRendering(t *testing.T) {
+	ui := CreateUI().(*UserInterface)
+	_ = ui.SetBorder(0)
+
+	ui.DrawElement(CreatePos(0, 0), "日本x", ALREADYCOLORED)
+	expected := []string{"日", continuationCell, "本", continuationCell, "x"}
+	for i, cell := range expected {
+		if ui.pixels[0][i] != cell {
+			t.Errorf("cell %d = %q, want %q", i, ui.pixels[0][i], cell)
+		}
+	}
+
+	// overwriting the right half of a wide cluster blanks its left half
+	ui.DrawClipped(Rect{Width: 10, Height: 1}, 1, 0, "a", ALREADYCOLORED)
+	if ui.pixels[0][0] != " " || ui.pixels[0][1] != "a" {
+		t.Errorf("broken wide cluster left behind: %q %q", ui.pixels[0][0], ui.pixels[0][1])
+	}
+
+	// erasing covers exactly the cells that were drawn
+	ui.DrawElement(CreatePos(0, 0), "日本x", BLANK)
+	if got := row(ui, 0, 0, 6); got != "      " {
+		t.Errorf("row after erase = %q", got)
+	}
+
+	// a wide cluster cut by the clip leaves a space
+	ui.DrawClipped(Rect{Width: 3, Height: 1}, 2, 0, "本", ALREADYCOLORED)
+	if ui.pixels[0][2] != " " || ui.pixels[0][3] != " " {
+		t.Errorf("clipped wide cluster drawn: %q %q", ui.pixels[0][2], ui.pixels[0][3])
+	}
+}